@@ -2,9 +2,12 @@ package main
 
 import (
 	"fmt"
+	"log"
+	"os"
 
 	"github.com/catmorte/go-ioc/examples/pkg/dependent"
-	_ "github.com/catmorte/go-ioc/examples/pkg/independent"
+	"github.com/catmorte/go-ioc/examples/pkg/independent"
+	"github.com/catmorte/go-ioc/pkg/config"
 	. "github.com/catmorte/go-ioc/pkg/context"
 )
 
@@ -15,9 +18,26 @@ func init() {
 }
 
 func main() {
+	err := config.Setup(
+		config.Map(map[string]any{"app.name": "example", "app.port": 8080, "app.timeout": "5s"}),
+		config.Env("EXAMPLE_"),
+		config.Args(os.Args[1:]),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
 	dependentBean1 := Ask[*dependent.DependentObj]()
 	dependentBean2 := Ask[*dependent.DependentObj]()
 	fmt.Println(dependentBean1.IndependentObj1.SomeDepField)
 	dependentBean1.IndependentObj3.SomeSpecificLogicFunc()
 	fmt.Println(dependentBean2.IndependentObj2.SimpleValue)
+	fmt.Println(dependentBean2.IndependentObj2.Greeting)
+	appConfig := AskScoped[*independent.AppConfig](config.Scope)
+	fmt.Println(appConfig.Name, appConfig.Port, appConfig.Timeout)
+
+	RegInterceptor[dependent.SomeInterface](func(inv *Invocation, proceed func()) {
+		fmt.Println("calling", inv.Method)
+		proceed()
+	})
+	AskInterface[dependent.SomeInterface]().SomeSpecificLogicFunc()
 }
@@ -1,4 +1,4 @@
-//go:generate go-ioc
+//go:generate go-ioc -proxy
 package dependent
 
 import (
@@ -6,18 +6,34 @@ import (
 	goIoc0 "github.com/catmorte/go-ioc/pkg/context"
 )
 
+type dependentObjIndependentObj3Proxy struct {
+	target       SomeInterface
+	interceptors goIoc0.Interceptors
+}
+
 func init() {
-	dep0_0 := goIoc0.DepScoped[*independent.IndependentObj1]("independentScope1")
-	dep0_1 := goIoc0.DepScoped[independent.IndependentObj2]("independentScope2")
-	dep0_2 := goIoc0.DepInterface[SomeInterface]()
-	goIoc0.RegPrototype(func() *DependentObj {
-		v := &DependentObj{
-			IndependentObj1: goIoc0.ResolveDep[*independent.IndependentObj1](dep0_0),
-			IndependentObj2: goIoc0.ResolveDep[independent.IndependentObj2](dep0_1),
-			IndependentObj3: goIoc0.ResolveDep[SomeInterface](dep0_2),
-		}
-		v.Init()
-		return v
-	}, dep0_0, dep0_1, dep0_2)
+	goIoc0.RegProxy(func(target SomeInterface, interceptors goIoc0.Interceptors) SomeInterface {
+		return &dependentObjIndependentObj3Proxy{target: target, interceptors: interceptors}
+	})
+	goIoc0.RegModule(func() {
+		dep0_0 := goIoc0.DepScoped[*independent.IndependentObj1]("independentScope1")
+		dep0_1 := goIoc0.DepScoped[independent.IndependentObj2]("independentScope2")
+		dep0_2 := goIoc0.DepInterface[SomeInterface]()
+		goIoc0.RegPrototype(func() *DependentObj {
+			v := &DependentObj{
+				IndependentObj1: goIoc0.ResolveDep[*independent.IndependentObj1](dep0_0),
+				IndependentObj2: goIoc0.ResolveDep[independent.IndependentObj2](dep0_1),
+				IndependentObj3: goIoc0.ResolveDep[SomeInterface](dep0_2),
+			}
+			v.Init()
+			return v
+		}, dep0_0, dep0_1, dep0_2)
+	})
+}
 
+func (p *dependentObjIndependentObj3Proxy) SomeSpecificLogicFunc() {
+	inv := &goIoc0.Invocation{Method: "SomeSpecificLogicFunc", Args: []any{}}
+	p.interceptors.Invoke(inv, func() {
+		p.target.SomeSpecificLogicFunc()
+	})
 }
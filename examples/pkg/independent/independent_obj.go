@@ -2,6 +2,8 @@
 package independent
 
 import (
+	"time"
+
 	singleton "github.com/catmorte/go-ioc/pkg/context/singleton"
 )
 
@@ -13,6 +15,7 @@ type IndependentObj1 struct {
 type IndependentObj2 struct {
 	singleton.Bean[IndependentObj2] `bean:"independentScope2"`
 	SimpleValue                     int
+	Greeting                        string `env:"EXAMPLE_GREETING" default:"hello"`
 }
 
 type independentObj3 struct {
@@ -20,6 +23,26 @@ type independentObj3 struct {
 	SimpleValue                     int
 }
 
+type independentObj4 struct {
+	singleton.Bean[*independentObj4] `bean:",interface,primary"`
+	SimpleValue                      int
+}
+
+type AppConfig struct {
+	singleton.Bean[*AppConfig] `bean:"config"`
+	Name                       string        `config:"app.name"`
+	Port                       int           `config:"app.port"`
+	Timeout                    time.Duration `config:"app.timeout"`
+}
+
+func (i *independentObj4) SomeSpecificLogicFunc() {
+	println(i.SimpleValue)
+}
+
+func (i *independentObj4) Init() {
+	i.SimpleValue = 96
+}
+
 func (i independentObj3) SomeSpecificLogicFunc() {
 	println(i.SimpleValue)
 }
@@ -2,27 +2,50 @@
 package independent
 
 import (
+	"errors"
+
+	goIocConfig0 "github.com/catmorte/go-ioc/pkg/config"
 	goIoc0 "github.com/catmorte/go-ioc/pkg/context"
 )
 
 func init() {
-	dep0_0 := goIoc0.DepScoped[string]("config")
-	goIoc0.RegScoped("independentScope1", func() *IndependentObj1 {
-		v := &IndependentObj1{
-			SomeDepField: goIoc0.ResolveDep[string](dep0_0),
-		}
-		v.Init()
-		return v
-	}, dep0_0)
-	goIoc0.RegScoped("independentScope2", func() IndependentObj2 {
-		v := IndependentObj2{}
-		v.Init()
-		return v
-	})
-	goIoc0.RegScoped("", func() independentObj3 {
-		v := independentObj3{}
-		v.Init()
-		return v
+	goIoc0.RegModule(func() {
+		dep0_0 := goIoc0.DepScoped[string]("config")
+		goIoc0.RegScoped("independentScope1", func() *IndependentObj1 {
+			v := &IndependentObj1{
+				SomeDepField: goIoc0.ResolveDep[string](dep0_0),
+			}
+			v.Init()
+			return v
+		}, dep0_0)
+		goIoc0.RegScopedE("independentScope2", func() (IndependentObj2, error) {
+			v := IndependentObj2{}
+			if err := errors.Join(
+				goIocConfig0.BindEnvOr(&v.Greeting, "EXAMPLE_GREETING", "hello"),
+			); err != nil {
+				return v, err
+			}
+			v.Init()
+			return v, nil
+		})
+		goIoc0.RegScoped("", func() independentObj3 {
+			v := independentObj3{}
+			v.Init()
+			return v
+		})
+		goIoc0.RegScoped("", func() *independentObj4 {
+			v := &independentObj4{}
+			v.Init()
+			return v
+		}, goIoc0.Primary())
+		dep4 := goIoc0.DepScoped[*goIocConfig0.Properties](goIocConfig0.Scope)
+		goIoc0.RegScopedE("config", func() (*AppConfig, error) {
+			v := &AppConfig{}
+			if err := goIocConfig0.Bind(goIoc0.ResolveDep[*goIocConfig0.Properties](dep4), &v); err != nil {
+				return v, err
+			}
+			v.Init()
+			return v, nil
+		}, dep4)
 	})
-
 }
@@ -7,8 +7,17 @@ const (
 	IocPkgSingletonAlias = "singleton"
 	IocPkgPrototypePath  = "github.com/catmorte/go-ioc/pkg/context/prototype"
 	IocPkgPrototypeAlias = "prototype"
+	IocPkgConfigPath     = "github.com/catmorte/go-ioc/pkg/config"
+	IocPkgConfigAlias    = "goIocConfig"
 
 	IocTag               = "bean"
 	IocBeanStructName    = "Bean"
 	IocInterfaceTagValue = "interface"
+	IocPrimaryTagValue   = "primary"
+	IocLazyTagValue      = "lazy"
+	IocProxySuffix       = "Proxy"
+	IocConfigTag         = "config"
+	IocEnvTag            = "env"
+	IocDefaultTag        = "default"
+	IocProfileTag        = "profile"
 )
@@ -16,9 +16,14 @@ type (
 		Name string
 	}
 	StructFieldMeta struct {
-		Name  string
-		Tag   *string
-		Index *IndexMeta
+		Name    string
+		Tag     *string
+		Config  *string
+		Env     *string
+		Default *string
+		Profile *string
+		Index   *IndexMeta
+		Methods []*Func
 	}
 	IndexMeta struct {
 		Field *Type[TypeMeta]
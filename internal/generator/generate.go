@@ -5,6 +5,9 @@ import (
 	"bytes"
 	"fmt"
 	"go/format"
+	"path"
+	"slices"
+	"strconv"
 	"strings"
 	"text/template"
 
@@ -24,18 +27,32 @@ import (
 )
 
 {{if .PrintRaw}}
+{{range .File.Structs}}
+type {{.Code}}
+{{end}}
 {{range .Funcs}}
 {{.Code}}
 {{end}}
 {{ else}}
 func init() {
+	{{if .Proxy -}}
+		{{range $proxy := Proxies .File.Structs -}}
+			{{$.IocPackageAlias}}RegProxy(func(target {{$proxy.Interface}}, interceptors {{$.IocPackageAlias}}Interceptors) {{$proxy.Interface}} {
+				return &{{$proxy.Name}}{target: target, interceptors: interceptors}
+			})
+		{{end -}}
+	{{end -}}
+	{{$.IocPackageAlias}}RegModule(func() {
 	{{range $structIndex, $struct := .File.Structs -}} 
 		{{range $fieldIndex, $field := $struct.Fields -}} 
 			{{if $field.Meta.Tag -}}
 				dep{{$structIndex}}_{{ $fieldIndex }} := {{$.IocPackageAlias}}{{Dep $field}}
   		{{end -}}
   	{{end -}}
-		{{$.IocPackageAlias}}{{Reg $struct $.File.Imports}}func() {{Ret $struct}} {
+		{{if isConfig $struct -}}
+			dep{{$structIndex}} := {{$.IocPackageAlias}}DepScoped[*{{$.ConfigPackageAlias}}Properties]({{PropertiesScope $struct $.ConfigPackageAlias}})
+		{{end -}}
+		{{$.IocPackageAlias}}{{Reg $struct $.File.Imports}}func() {{if returnsError $struct}}({{Ret $struct}}, error){{else}}{{Ret $struct}}{{end}} {
 			v := {{if isPtr $struct}}&{{end}}{{$struct.Name}}{
 				{{range $fieldIndex, $field := $struct.Fields -}} 
 					{{if $field.Meta.Tag -}} 
@@ -43,30 +60,102 @@ func init() {
   				{{end -}} 
   			{{end -}}
 			}
+			{{if isConfig $struct -}}
+				if err := {{$.ConfigPackageAlias}}Bind({{$.IocPackageAlias}}ResolveDep[*{{$.ConfigPackageAlias}}Properties](dep{{$structIndex}}), &v); err != nil {
+					return v, err
+				}
+			{{end -}}
+			{{if hasEnv $struct -}}
+				if err := errors.Join(
+					{{range $field := $struct.Fields -}}
+						{{if $field.Meta.Env -}}
+							{{$.ConfigPackageAlias}}{{Env $field}},
+						{{end -}}
+					{{end -}}
+				); err != nil {
+					return v, err
+				}
+			{{end -}}
 			v.Init()
-			return v
+			return v{{if returnsError $struct}}, nil{{end}}
 		}, {{range $fieldIndex, $field := $struct.Fields -}} 
 			{{- if $field.Meta.Tag -}} 
 				dep{{$structIndex}}_{{ $fieldIndex }},
   		{{- end -}} 
   	{{- end -}}
+  	{{- if isConfig $struct -}}
+				dep{{$structIndex}},
+  	{{- end -}}
+  	{{- range Opts $struct -}}
+				{{$.IocPackageAlias}}{{.}},
+  	{{- end -}}
   )
-  {{end }}
+  {{end -}}
+	})
+}
+
+{{if .Proxy -}}
+{{range $proxy := Proxies .File.Structs}}
+type {{$proxy.Name}} struct {
+	target       {{$proxy.Interface}}
+	interceptors {{$.IocPackageAlias}}Interceptors
 }
+{{range $method := $proxy.Methods}}
+func (p *{{$proxy.Name}}) {{$method.Name}}({{Params $method}}) ({{Results $method}}) {
+	inv := &{{$.IocPackageAlias}}Invocation{Method: "{{$method.Name}}", Args: []any{ {{Args $method false}} }}
+	p.interceptors.Invoke(inv, func() {
+		{{if $method.Results}}{{ResultNames $method}} = {{end}}p.target.{{$method.Name}}({{Args $method true}})
+		{{- if $method.Results}}
+		inv.Results = []any{ {{ResultNames $method}} }
+		{{- end}}
+	})
+	{{if $method.Results -}}
+	if len(inv.Results) == {{len $method.Results}} {
+		{{range $i, $result := $method.Results -}}
+			r{{$i}}, _ = inv.Results[{{$i}}].({{$result.Code}})
+		{{end -}}
+	}
+	return
+	{{end -}}
+}
+{{end}}
+{{end}}
+{{end}}
 {{end }}
 `
 
+type proxy struct {
+	Name      string
+	Interface string
+	Methods   []*declaration.Func
+}
+
+type beanTag struct {
+	Scope string
+	Flags []string
+}
+
 type fileTemplateData struct {
-	PackageName     string
-	IocPackageAlias string
-	PrintRaw        bool
+	PackageName        string
+	IocPackageAlias    string
+	ConfigPackageAlias string
+	PrintRaw           bool
+	Proxy              bool
 	declaration.File
 }
 
 func getIocPrefix(imports []*declaration.Import) string {
+	return getImportPrefix(imports, declaration.IocPkgContextPath)
+}
+
+func getConfigPrefix(imports []*declaration.Import) string {
+	return getImportPrefix(imports, declaration.IocPkgConfigPath)
+}
+
+func getImportPrefix(imports []*declaration.Import, path string) string {
 	var imp *declaration.Import
 	for _, i := range imports {
-		if i.Path == declaration.IocPkgContextPath && i.Alias != "_" && i.Alias != "" {
+		if i.Path == path && i.Alias != "_" && i.Alias != "" {
 			imp = i
 		}
 	}
@@ -76,10 +165,100 @@ func getIocPrefix(imports []*declaration.Import) string {
 	return fmt.Sprintf("%s.", imp.Alias)
 }
 
-func getRegFuncName(imp *declaration.Import, f *declaration.Type[declaration.StructFieldMeta]) string {
-	suffix := "("
+func parseBeanTag(tag string) beanTag {
+	params := strings.Split(tag, ",")
+	flags := make([]string, 0, len(params)-1)
+	for _, p := range params[1:] {
+		flags = append(flags, strings.TrimSpace(p))
+	}
+	return beanTag{Scope: strings.TrimSpace(params[0]), Flags: flags}
+}
+
+func isConfig(s *declaration.Struct) bool {
+	for _, f := range s.Fields {
+		if f.Meta.Config != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func hasEnv(s *declaration.Struct) bool {
+	for _, f := range s.Fields {
+		if f.Meta.Env != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func returnsError(s *declaration.Struct) bool {
+	return isConfig(s) || hasEnv(s)
+}
+
+func getProxies(structs []*declaration.Struct) []proxy {
+	proxies := []proxy{}
+	for _, s := range structs {
+		for _, f := range s.Fields {
+			if f.Meta.Tag == nil || len(f.Meta.Methods) == 0 ||
+				!slices.Contains(parseBeanTag(*f.Meta.Tag).Flags, declaration.IocInterfaceTagValue) {
+				continue
+			}
+			proxies = append(proxies, proxy{
+				Name:      strings.ToLower(s.Name[:1]) + s.Name[1:] + f.Meta.Name + declaration.IocProxySuffix,
+				Interface: f.Code,
+				Methods:   f.Meta.Methods,
+			})
+		}
+	}
+	return proxies
+}
+
+func getParams(fn *declaration.Func) string {
+	params := make([]string, 0, len(fn.Params))
+	for i, p := range fn.Params {
+		params = append(params, fmt.Sprintf("a%d %s", i, p.Code))
+	}
+	return strings.Join(params, ", ")
+}
+
+func getArgs(fn *declaration.Func, spread bool) string {
+	args := make([]string, 0, len(fn.Params))
+	for i, p := range fn.Params {
+		arg := fmt.Sprintf("a%d", i)
+		if spread && p.Meta.IsVararg {
+			arg += "..."
+		}
+		args = append(args, arg)
+	}
+	return strings.Join(args, ", ")
+}
+
+func getResults(fn *declaration.Func) string {
+	results := make([]string, 0, len(fn.Results))
+	for i, r := range fn.Results {
+		results = append(results, fmt.Sprintf("r%d %s", i, r.Code))
+	}
+	return strings.Join(results, ", ")
+}
+
+func getResultNames(fn *declaration.Func) string {
+	results := make([]string, 0, len(fn.Results))
+	for i := range fn.Results {
+		results = append(results, fmt.Sprintf("r%d", i))
+	}
+	return strings.Join(results, ", ")
+}
+
+func getRegFuncName(imp *declaration.Import, s *declaration.Struct) string {
+	f := s.Bean
+	errSuffix := ""
+	if returnsError(s) {
+		errSuffix = "E"
+	}
+	suffix := errSuffix + "("
 	if f.Meta.Tag != nil {
-		suffix = fmt.Sprintf("Scoped(\"%s\",", (*f.Meta.Tag))
+		suffix = fmt.Sprintf("Scoped%s(\"%s\",", errSuffix, parseBeanTag(*f.Meta.Tag).Scope)
 	}
 	if imp.Path == declaration.IocPkgSingletonPath {
 		return "Reg" + suffix
@@ -89,6 +268,14 @@ func getRegFuncName(imp *declaration.Import, f *declaration.Type[declaration.Str
 
 func parseTemplate() (*template.Template, error) {
 	return template.New("").Funcs(template.FuncMap{
+		"Proxies":      getProxies,
+		"Params":       getParams,
+		"Args":         getArgs,
+		"Results":      getResults,
+		"ResultNames":  getResultNames,
+		"isConfig":     isConfig,
+		"hasEnv":       hasEnv,
+		"returnsError": returnsError,
 		"isPtr": func(s *declaration.Struct) bool {
 			return strings.HasPrefix(s.Bean.Meta.Index.Index.Code, "*")
 		},
@@ -98,38 +285,66 @@ func parseTemplate() (*template.Template, error) {
 		},
 		"Reg": func(s *declaration.Struct, imports []*declaration.Import) string {
 			for _, imp := range imports {
-				if imp.Alias == "_" {
+				alias := imp.Alias
+				if alias == "_" {
 					continue
 				}
-				if imp.Alias == "" &&
-					(strings.HasPrefix(s.Bean.Meta.Index.Field.Code, declaration.IocPkgSingletonAlias+".") ||
-						strings.HasPrefix(s.Bean.Meta.Index.Field.Code, declaration.IocPkgPrototypeAlias+".")) {
-					return getRegFuncName(imp, s.Bean)
+				if alias == "" {
+					alias = path.Base(imp.Path)
 				}
-				if imp.Alias == "." && strings.HasPrefix(s.Bean.Meta.Index.Field.Code, declaration.IocBeanStructName) {
-					return getRegFuncName(imp, s.Bean)
+				if alias == "." && strings.HasPrefix(s.Bean.Meta.Index.Field.Code, declaration.IocBeanStructName) {
+					return getRegFuncName(imp, s)
 				}
-				if strings.HasPrefix(s.Bean.Meta.Index.Field.Code, imp.Alias+".") {
-					return getRegFuncName(imp, s.Bean)
+				if strings.HasPrefix(s.Bean.Meta.Index.Field.Code, alias+".") {
+					return getRegFuncName(imp, s)
 				}
 			}
 			return "RegUnknown"
 		},
+		"PropertiesScope": func(s *declaration.Struct, configAlias string) string {
+			if s.Bean.Meta.Config != nil {
+				return strconv.Quote(strings.TrimSpace(*s.Bean.Meta.Config))
+			}
+			return configAlias + "Scope"
+		},
+		"Opts": func(s *declaration.Struct) []string {
+			opts := []string{}
+			if s.Bean.Meta.Profile != nil {
+				profiles := []string{}
+				for _, p := range strings.Split(*s.Bean.Meta.Profile, ",") {
+					profiles = append(profiles, strconv.Quote(strings.TrimSpace(p)))
+				}
+				opts = append(opts, fmt.Sprintf("Profile(%s)", strings.Join(profiles, ", ")))
+			}
+			if s.Bean.Meta.Tag == nil {
+				return opts
+			}
+			flags := parseBeanTag(*s.Bean.Meta.Tag).Flags
+			if slices.Contains(flags, declaration.IocPrimaryTagValue) {
+				opts = append(opts, "Primary()")
+			}
+			if slices.Contains(flags, declaration.IocLazyTagValue) {
+				opts = append(opts, "Lazy()")
+			}
+			return opts
+		},
+		"Env": func(f *declaration.Type[declaration.StructFieldMeta]) string {
+			if f.Meta.Default != nil {
+				return fmt.Sprintf("BindEnvOr(&v.%s, %q, %q)", f.Meta.Name, *f.Meta.Env, *f.Meta.Default)
+			}
+			return fmt.Sprintf("BindEnv(&v.%s, %q)", f.Meta.Name, *f.Meta.Env)
+		},
 		"Dep": func(f *declaration.Type[declaration.StructFieldMeta]) string {
 			if f.Meta.Tag != nil {
 				name := "Dep"
 				scopeArgument := ""
-				params := strings.Split(*f.Meta.Tag, ",")
-				scope := params[0]
-				if len(params) > 1 {
-					if params[1] == declaration.IocInterfaceTagValue {
-						name += "Interface"
-					}
+				tag := parseBeanTag(*f.Meta.Tag)
+				if slices.Contains(tag.Flags, declaration.IocInterfaceTagValue) {
+					name += "Interface"
 				}
-				scope = strings.TrimSpace(scope)
-				if scope != "" {
+				if tag.Scope != "" {
 					name += "Scoped"
-					scopeArgument = `"` + scope + `"`
+					scopeArgument = `"` + tag.Scope + `"`
 				}
 				return fmt.Sprintf("%s[%s](%s)", name, f.Code, scopeArgument)
 			}
@@ -147,12 +362,14 @@ func executeTemplate[T any](t *template.Template, data T) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-func newFileTemplateData(prefix string, packageName string, f declaration.File, printRaw bool) fileTemplateData {
+func newFileTemplateData(prefix string, configPrefix string, packageName string, f declaration.File, printRaw bool, proxy bool) fileTemplateData {
 	return fileTemplateData{
-		PackageName:     packageName,
-		File:            f,
-		IocPackageAlias: prefix,
-		PrintRaw:        printRaw,
+		Proxy:              proxy,
+		PackageName:        packageName,
+		File:               f,
+		IocPackageAlias:    prefix,
+		ConfigPackageAlias: configPrefix,
+		PrintRaw:           printRaw,
 	}
 }
 
@@ -164,9 +381,10 @@ func formatImports(b []byte) ([]byte, error) {
 	return imports.Process("", b, nil)
 }
 
-func Generate(packageName string, f declaration.File, printRaw bool) Out[[]byte] {
+func Generate(packageName string, f declaration.File, printRaw bool, proxy bool) Out[[]byte] {
 	gotIocPreifx := getIocPrefixWrap(f.Imports)
-	templateDataCreated := AndX4Async(gotIocPreifx, OK(packageName), OK(f), OK(printRaw), newFileTemplateDataWrap)
+	gotConfigPrefix := getConfigPrefixWrap(f.Imports)
+	templateDataCreated := AndX6Async(gotIocPreifx, gotConfigPrefix, OK(packageName), OK(f), OK(printRaw), OK(proxy), newFileTemplateDataWrap)
 	templateParsed := parseTemplateWrap()
 	return AndX2Async(templateParsed, templateDataCreated, func(t *template.Template, data fileTemplateData) Out[[]byte] {
 		codeGenerated := executeTemplateWrap(t, data)
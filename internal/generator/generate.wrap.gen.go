@@ -12,7 +12,51 @@ func getIocPrefixWrap(arg0 []*declaration.Import) Out[string] {
 	return OK(getIocPrefix(arg0))
 }
 
-func getRegFuncNameWrap(arg0 *declaration.Import, arg1 *declaration.Type[declaration.StructFieldMeta]) Out[string] {
+func getConfigPrefixWrap(arg0 []*declaration.Import) Out[string] {
+	return OK(getConfigPrefix(arg0))
+}
+
+func getImportPrefixWrap(arg0 []*declaration.Import, arg1 string) Out[string] {
+	return OK(getImportPrefix(arg0, arg1))
+}
+
+func parseBeanTagWrap(arg0 string) Out[beanTag] {
+	return OK(parseBeanTag(arg0))
+}
+
+func isConfigWrap(arg0 *declaration.Struct) Out[bool] {
+	return OK(isConfig(arg0))
+}
+
+func hasEnvWrap(arg0 *declaration.Struct) Out[bool] {
+	return OK(hasEnv(arg0))
+}
+
+func returnsErrorWrap(arg0 *declaration.Struct) Out[bool] {
+	return OK(returnsError(arg0))
+}
+
+func getProxiesWrap(arg0 []*declaration.Struct) Out[[]proxy] {
+	return OK(getProxies(arg0))
+}
+
+func getParamsWrap(arg0 *declaration.Func) Out[string] {
+	return OK(getParams(arg0))
+}
+
+func getArgsWrap(arg0 *declaration.Func, arg1 bool) Out[string] {
+	return OK(getArgs(arg0, arg1))
+}
+
+func getResultsWrap(arg0 *declaration.Func) Out[string] {
+	return OK(getResults(arg0))
+}
+
+func getResultNamesWrap(arg0 *declaration.Func) Out[string] {
+	return OK(getResultNames(arg0))
+}
+
+func getRegFuncNameWrap(arg0 *declaration.Import, arg1 *declaration.Struct) Out[string] {
 	return OK(getRegFuncName(arg0, arg1))
 }
 
@@ -24,8 +68,8 @@ func executeTemplateWrap[T any](arg0 *template.Template, arg1 T) Out[[]byte] {
 	return Wrap(executeTemplate(arg0, arg1))
 }
 
-func newFileTemplateDataWrap(arg0 string, arg1 string, arg2 declaration.File, arg3 bool) Out[fileTemplateData] {
-	return OK(newFileTemplateData(arg0, arg1, arg2, arg3))
+func newFileTemplateDataWrap(arg0 string, arg1 string, arg2 string, arg3 declaration.File, arg4 bool, arg5 bool) Out[fileTemplateData] {
+	return OK(newFileTemplateData(arg0, arg1, arg2, arg3, arg4, arg5))
 }
 
 func formatSourceWrap(arg0 []byte) Out[[]byte] {
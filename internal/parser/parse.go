@@ -5,7 +5,9 @@ import (
 	"bytes"
 	"go/ast"
 	"go/printer"
+	"go/types"
 	"os"
+	"reflect"
 	"strconv"
 	"strings"
 
@@ -94,27 +96,76 @@ func (p packageParser) newStructType(f *ast.Field) *declaration.Type[declaration
 	return &declaration.Type[declaration.StructFieldMeta]{
 		Code: p.extractRawCode(f.Type),
 		Meta: declaration.StructFieldMeta{
-			Name:  name,
-			Tag:   getBeanTagValue(f.Tag, declaration.IocTag),
-			Index: index,
+			Name:    name,
+			Tag:     getBeanTagValue(f.Tag, declaration.IocTag),
+			Config:  getBeanTagValue(f.Tag, declaration.IocConfigTag),
+			Env:     getBeanTagValue(f.Tag, declaration.IocEnvTag),
+			Default: getBeanTagValue(f.Tag, declaration.IocDefaultTag),
+			Profile: getBeanTagValue(f.Tag, declaration.IocProfileTag),
+			Index:   index,
+			Methods: p.interfaceMethods(f.Type),
 		},
 	}
 }
 
+func (p packageParser) interfaceMethods(expr ast.Expr) []*declaration.Func {
+	if p.TypesInfo == nil {
+		return nil
+	}
+	t := p.TypesInfo.TypeOf(expr)
+	if t == nil {
+		return nil
+	}
+	iface, ok := t.Underlying().(*types.Interface)
+	if !ok {
+		return nil
+	}
+	qualifier := func(pkg *types.Package) string {
+		if pkg == p.Types {
+			return ""
+		}
+		return pkg.Name()
+	}
+	methods := make([]*declaration.Func, 0, iface.NumMethods())
+	for i := 0; i < iface.NumMethods(); i++ {
+		method := iface.Method(i)
+		signature := method.Type().(*types.Signature)
+		fn := &declaration.Func{Name: method.Name()}
+		for j := 0; j < signature.Params().Len(); j++ {
+			param := signature.Params().At(j).Type()
+			isVararg := signature.Variadic() && j == signature.Params().Len()-1
+			code := types.TypeString(param, qualifier)
+			if isVararg {
+				code = "..." + types.TypeString(param.(*types.Slice).Elem(), qualifier)
+			}
+			fn.Params = append(fn.Params, &declaration.Type[declaration.ParamMeta]{
+				Code: code,
+				Meta: declaration.ParamMeta{IsVararg: isVararg},
+			})
+		}
+		for j := 0; j < signature.Results().Len(); j++ {
+			fn.Results = append(fn.Results, &declaration.Type[Empty]{
+				Code: types.TypeString(signature.Results().At(j).Type(), qualifier),
+			})
+		}
+		methods = append(methods, fn)
+	}
+	return methods
+}
+
 func getBeanTagValue(tagObj *ast.BasicLit, key string) *string {
 	if tagObj == nil {
 		return nil
 	}
-	tag := strings.Trim(tagObj.Value, "`")
-	parts := strings.Split(tag, " ")
-	for _, part := range parts {
-		kv := strings.SplitN(part, ":", 2)
-		if len(kv) == 2 && strings.Trim(kv[0], " ") == key {
-			v := strings.Trim(kv[1], "\"")
-			return &v
-		}
+	tag, err := strconv.Unquote(tagObj.Value)
+	if err != nil {
+		return nil
+	}
+	v, ok := reflect.StructTag(tag).Lookup(key)
+	if !ok {
+		return nil
 	}
-	return nil
+	return &v
 }
 
 func newPackageParser(p *packages.Package) packageParser {
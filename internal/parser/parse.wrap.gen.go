@@ -41,6 +41,10 @@ func (rcv packageParser) newStructTypeWrap(arg0 *ast.Field) Out[*declaration.Typ
 	return OK(rcv.newStructType(arg0))
 }
 
+func (rcv packageParser) interfaceMethodsWrap(arg0 ast.Expr) Out[[]*declaration.Func] {
+	return OK(rcv.interfaceMethods(arg0))
+}
+
 func getBeanTagValueWrap(arg0 *ast.BasicLit, arg1 string) Out[*string] {
 	return OK(getBeanTagValue(arg0, arg1))
 }
@@ -62,6 +62,18 @@ func findImportByPath(i []*declaration.Import, pkg string) bool {
 	return false
 }
 
+func addImport(imports []*declaration.Import, path string, aliasPrefix string) []*declaration.Import {
+	if findImportByPath(imports, path) {
+		return imports
+	}
+	for i := 0; ; i++ {
+		alias := fmt.Sprintf("%s%d", aliasPrefix, i)
+		if !findImportByAlias(imports, alias) {
+			return append(imports, &declaration.Import{Alias: alias, Path: path})
+		}
+	}
+}
+
 func findFile(f string, vc []*declaration.File) *declaration.File {
 	for _, v := range vc {
 		if v.Path == f {
@@ -99,6 +111,7 @@ func filterStructs(structs []*declaration.Struct) []*declaration.Struct {
 
 func main() {
 	fileFlag := flag.String("file", "", "file")
+	proxyFlag := flag.Bool("proxy", false, "generate interceptor proxies for interface dependencies")
 	flag.Parse()
 	file := os.Getenv("GOFILE")
 
@@ -121,23 +134,11 @@ func main() {
 			}
 			f.Structs = filterStructs(f.Structs)
 
-			ok := findImportByPath(f.Imports, declaration.IocPkgContextPath)
-			if !ok {
-				i := 0
-				alias := ""
-				for {
-					alias = fmt.Sprintf("%s%d", declaration.IocPkgAlias, i)
-					ok = findImportByAlias(f.Imports, alias)
-					if !ok {
-						f.Imports = append(f.Imports, &declaration.Import{Alias: alias, Path: declaration.IocPkgContextPath})
-						break
-					}
-					i++
-				}
-			}
+			f.Imports = addImport(f.Imports, declaration.IocPkgContextPath, declaration.IocPkgAlias)
+			f.Imports = addImport(f.Imports, declaration.IocPkgConfigPath, declaration.IocPkgConfigAlias)
 			fileName := fmt.Sprintf("%s.ioc.gen.go", strings.TrimSuffix(file, filepath.Ext(file)))
 			fullPath := filepath.Join(path, fileName)
-			codeGenerated := generator.Generate(p.Name, *f, false)
+			codeGenerated := generator.Generate(p.Name, *f, false, *proxyFlag)
 			return And(codeGenerated, func(raw []byte) Out[string] {
 				return Wrap(fullPath, os.WriteFile(fullPath, raw, 0o644))
 			})
@@ -154,7 +155,7 @@ func main() {
 			unusedImports := findUnusedDotImportsForFile(filePath, p.Errors)
 			f.Imports = removeUnusedDotImports(unusedImports, f.Imports)
 
-			codeGenerated := generator.Generate(p.Name, *f, true)
+			codeGenerated := generator.Generate(p.Name, *f, true, *proxyFlag)
 			return And(codeGenerated, func(raw []byte) Out[Empty] {
 				return Void(os.WriteFile(filePath, raw, 0o644))
 			})
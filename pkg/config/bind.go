@@ -0,0 +1,166 @@
+package config
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const Tag = "config"
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+type BindError struct {
+	Key   string
+	Field string
+	Err   error
+}
+
+func (e *BindError) Error() string {
+	return fmt.Sprintf("config key %q can't be bound to %s: %v", e.Key, e.Field, e.Err)
+}
+
+func (e *BindError) Unwrap() error {
+	return e.Err
+}
+
+func Bind(p *Properties, target any) error {
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return fmt.Errorf("config target must be a non-nil pointer, got %T", target)
+	}
+	v = v.Elem()
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("config target must point to a struct, got %T", target)
+	}
+	return bindStruct(p, v)
+}
+
+func bindStruct(p *Properties, v reflect.Value) error {
+	t := v.Type()
+	var errs []error
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		key, ok := field.Tag.Lookup(Tag)
+		if !ok {
+			if field.Type.Kind() == reflect.Struct {
+				errs = append(errs, bindStruct(p, v.Field(i)))
+			}
+			continue
+		}
+		raw, ok := p.Get(key)
+		if !ok {
+			continue
+		}
+		if err := assign(v.Field(i), raw); err != nil {
+			errs = append(errs, &BindError{Key: key, Field: t.String() + "." + field.Name, Err: err})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func assign(v reflect.Value, raw any) error {
+	switch value := raw.(type) {
+	case string:
+		return parseInto(v, value)
+	case float64:
+		return parseInto(v, strconv.FormatFloat(value, 'f', -1, 64))
+	case []any:
+		if v.Kind() != reflect.Slice {
+			return fmt.Errorf("list can't be assigned to %v", v.Type())
+		}
+		slice := reflect.MakeSlice(v.Type(), len(value), len(value))
+		for i, item := range value {
+			if err := assign(slice.Index(i), item); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+		return nil
+	case map[string]any:
+		return fmt.Errorf("section can't be assigned to %v", v.Type())
+	}
+	if rv := reflect.ValueOf(raw); rv.Type().AssignableTo(v.Type()) {
+		v.Set(rv)
+		return nil
+	}
+	return parseInto(v, fmt.Sprint(raw))
+}
+
+func parseInto(v reflect.Value, s string) error {
+	if v.CanAddr() {
+		if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			return u.UnmarshalText([]byte(s))
+		}
+	}
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		var parts []string
+		if strings.TrimSpace(s) != "" {
+			parts = strings.Split(s, ",")
+		}
+		slice := reflect.MakeSlice(v.Type(), len(parts), len(parts))
+		for i, part := range parts {
+			if err := parseInto(slice.Index(i), strings.TrimSpace(part)); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+	case reflect.Pointer:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return parseInto(v.Elem(), s)
+	default:
+		return fmt.Errorf("unsupported type %v", v.Type())
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/catmorte/go-ioc/pkg/context"
+)
+
+const Scope = "config"
+
+type (
+	Source interface {
+		Load() (map[string]any, error)
+	}
+
+	SourceFunc func() (map[string]any, error)
+
+	Properties struct {
+		tree map[string]any
+	}
+)
+
+func (f SourceFunc) Load() (map[string]any, error) {
+	return f()
+}
+
+func Load(sources ...Source) (*Properties, error) {
+	p := &Properties{tree: map[string]any{}}
+	for _, source := range sources {
+		values, err := source.Load()
+		if err != nil {
+			return nil, err
+		}
+		merge(p.tree, values)
+	}
+	return p, nil
+}
+
+func (p *Properties) Get(key string) (any, bool) {
+	var node any = p.tree
+	for _, part := range splitKey(key) {
+		tree, ok := node.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if node, ok = tree[part]; !ok {
+			return nil, false
+		}
+	}
+	return node, true
+}
+
+func (p *Properties) Tree() map[string]any {
+	return p.tree
+}
+
+func Setup(sources ...Source) error {
+	return SetupScoped(Scope, sources...)
+}
+
+func SetupScoped(scope string, sources ...Source) error {
+	props, err := Load(sources...)
+	context.RegScopedE(scope, func() (*Properties, error) {
+		return props, err
+	})
+	return err
+}
+
+func Register[T any](options ...context.RegOption) {
+	RegisterScoped[T](Scope, Scope, options...)
+}
+
+func RegisterScoped[T any](propertiesScope, scope string, options ...context.RegOption) {
+	propsDep := context.DepScoped[*Properties](propertiesScope)
+	context.RegScopedE(scope, func() (T, error) {
+		var v T
+		err := Bind(context.ResolveDep[*Properties](propsDep), &v)
+		return v, err
+	}, append(options, propsDep)...)
+}
+
+func merge(dst map[string]any, src map[string]any) {
+	for key, value := range src {
+		path := splitKey(key)
+		node := dst
+		for _, part := range path[:len(path)-1] {
+			next, ok := node[part].(map[string]any)
+			if !ok {
+				next = map[string]any{}
+				node[part] = next
+			}
+			node = next
+		}
+		last := path[len(path)-1]
+		if values, ok := value.(map[string]any); ok {
+			next, ok := node[last].(map[string]any)
+			if !ok {
+				next = map[string]any{}
+				node[last] = next
+			}
+			merge(next, values)
+			continue
+		}
+		node[last] = value
+	}
+}
+
+func splitKey(key string) []string {
+	return strings.Split(strings.ToLower(strings.TrimSpace(key)), ".")
+}
@@ -0,0 +1,201 @@
+package config
+
+import (
+	stdcontext "context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/catmorte/go-ioc/pkg/context"
+)
+
+type dbConfig struct {
+	Host    string        `config:"db.host"`
+	Port    int           `config:"db.port"`
+	Timeout time.Duration `config:"db.timeout"`
+	Debug   bool          `config:"db.debug"`
+	Tags    []string      `config:"db.tags"`
+	Pool    poolConfig
+}
+
+type poolConfig struct {
+	Size    uint    `config:"db.pool.size"`
+	Ratio   float64 `config:"db.pool.ratio"`
+	Missing string  `config:"db.pool.missing"`
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad_Layering(t *testing.T) {
+	jsonPath := writeFile(t, "config.json", `{"db": {"host": "json", "port": 5432, "tags": ["a", "b"], "pool": {"size": 4}}}`)
+	filePath := writeFile(t, "config.yaml", "# comment\ndb:\n  host: file\n  timeout: 2s\n  pool:\n    ratio: 0.5\nDB_DEBUG=true\n")
+	t.Setenv("CFGTEST_DB_PORT", "6543")
+
+	props, err := Load(
+		JSONFile(jsonPath),
+		File(filePath),
+		Env("CFGTEST_"),
+		Args([]string{"--db.host=args", "positional"}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := dbConfig{}
+	if err := Bind(props, &cfg); err != nil {
+		t.Fatal(err)
+	}
+	expected := dbConfig{
+		Host:    "args",
+		Port:    6543,
+		Timeout: 2 * time.Second,
+		Debug:   true,
+		Tags:    []string{"a", "b"},
+		Pool:    poolConfig{Size: 4, Ratio: 0.5},
+	}
+	if !reflect.DeepEqual(cfg, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, cfg)
+	}
+}
+
+func TestBind_Errors(t *testing.T) {
+	props, err := Load(Map(map[string]any{"db.port": "not a number", "db": map[string]any{"debug": "maybe"}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = Bind(props, &dbConfig{})
+	var bindErr *BindError
+	if !errors.As(err, &bindErr) || bindErr.Key != "db.port" {
+		t.Fatalf("Expected BindError for db.port, got %v", err)
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("Expected parse error to be wrapped, got %v", err)
+	}
+	if err := Bind(props, dbConfig{}); err == nil {
+		t.Errorf("Expected error for non-pointer target")
+	}
+}
+
+func TestParseFile_Invalid(t *testing.T) {
+	if _, err := Load(File(writeFile(t, "broken.env", "just text"))); err == nil {
+		t.Errorf("Expected error for malformed line")
+	}
+}
+
+func TestRegister(t *testing.T) {
+	previous := context.GetContext()
+	context.SetContext(context.NewMemoryContext())
+	t.Cleanup(func() { context.SetContext(previous) })
+
+	Register[*dbConfig]()
+	RegisterScoped[poolConfig](Scope, "pool")
+	if err := Setup(Map(map[string]any{"db.host": "localhost", "db.pool.size": 8})); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), time.Second)
+	defer cancel()
+	cfg, err := context.AskScopedCtx[*dbConfig](ctx, Scope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Host != "localhost" || cfg.Pool.Size != 8 {
+		t.Errorf("Unexpected config %+v", cfg)
+	}
+	pool, err := context.AskScopedCtx[poolConfig](ctx, "pool")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pool.Size != 8 {
+		t.Errorf("Expected pool size 8, got %v", pool.Size)
+	}
+}
+
+func TestRegister_CustomPropertiesScope(t *testing.T) {
+	previous := context.GetContext()
+	context.SetContext(context.NewMemoryContext())
+	t.Cleanup(func() { context.SetContext(previous) })
+
+	RegisterScoped[*dbConfig]("custom", "custom")
+	RegisterScoped[poolConfig]("custom", "pool")
+	if err := SetupScoped("custom", Map(map[string]any{"db.host": "custom-host", "db.pool.size": 4})); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), time.Second)
+	defer cancel()
+	cfg, err := context.AskScopedCtx[*dbConfig](ctx, "custom")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Host != "custom-host" {
+		t.Errorf("Expected host custom-host, got %v", cfg.Host)
+	}
+	pool, err := context.AskScopedCtx[poolConfig](ctx, "pool")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pool.Size != 4 {
+		t.Errorf("Expected pool size 4, got %v", pool.Size)
+	}
+}
+
+func TestRegister_BindFailure(t *testing.T) {
+	previous := context.GetContext()
+	context.SetContext(context.NewMemoryContext())
+	t.Cleanup(func() { context.SetContext(previous) })
+
+	Register[dbConfig]()
+	Setup(Map(map[string]any{"db.port": "x"}))
+
+	ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), time.Second)
+	defer cancel()
+	_, err := context.AskScopedCtx[dbConfig](ctx, Scope)
+	var beanErr *context.BeanError
+	var bindErr *BindError
+	if !errors.As(err, &beanErr) || !errors.As(err, &bindErr) {
+		t.Errorf("Expected BeanError wrapping BindError, got %v", err)
+	}
+}
+
+func TestBindEnv(t *testing.T) {
+	t.Setenv("CFGTEST_PORT", "9090")
+	t.Setenv("CFGTEST_HOSTS", "a, b")
+	t.Setenv("CFGTEST_BROKEN", "yes please")
+
+	var port int
+	var hosts []string
+	var timeout time.Duration
+	err := errors.Join(
+		BindEnv(&port, "CFGTEST_PORT"),
+		BindEnv(&hosts, "CFGTEST_HOSTS"),
+		BindEnvOr(&timeout, "CFGTEST_TIMEOUT", "3s"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if port != 9090 || !reflect.DeepEqual(hosts, []string{"a", "b"}) || timeout != 3*time.Second {
+		t.Errorf("Unexpected values %v %v %v", port, hosts, timeout)
+	}
+
+	var debug bool
+	err = errors.Join(BindEnv(&debug, "CFGTEST_BROKEN"), BindEnv(&port, "CFGTEST_UNSET"))
+	var envErr *EnvError
+	if !errors.As(err, &envErr) || envErr.Name != "CFGTEST_BROKEN" {
+		t.Errorf("Expected EnvError for CFGTEST_BROKEN, got %v", err)
+	}
+	if !errors.Is(err, ErrEnvNotSet) {
+		t.Errorf("Expected ErrEnvNotSet, got %v", err)
+	}
+}
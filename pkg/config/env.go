@@ -0,0 +1,46 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+)
+
+var ErrEnvNotSet = errors.New("not set")
+
+type EnvError struct {
+	Name string
+	Err  error
+}
+
+func (e *EnvError) Error() string {
+	return fmt.Sprintf("environment variable %q: %v", e.Name, e.Err)
+}
+
+func (e *EnvError) Unwrap() error {
+	return e.Err
+}
+
+func BindEnv[T any](target *T, name string) error {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return &EnvError{Name: name, Err: ErrEnvNotSet}
+	}
+	return parseEnv(target, name, value)
+}
+
+func BindEnvOr[T any](target *T, name string, def string) error {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		value = def
+	}
+	return parseEnv(target, name, value)
+}
+
+func parseEnv(target any, name string, value string) error {
+	if err := parseInto(reflect.ValueOf(target).Elem(), value); err != nil {
+		return &EnvError{Name: name, Err: err}
+	}
+	return nil
+}
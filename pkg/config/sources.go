@@ -0,0 +1,131 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+type yamlSection struct {
+	indent int
+	key    string
+}
+
+func Map(values map[string]any) Source {
+	return SourceFunc(func() (map[string]any, error) {
+		return values, nil
+	})
+}
+
+func JSONFile(path string) Source {
+	return SourceFunc(func() (map[string]any, error) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		values := map[string]any{}
+		if err := json.Unmarshal(data, &values); err != nil {
+			return nil, fmt.Errorf("config file %s: %w", path, err)
+		}
+		return values, nil
+	})
+}
+
+func File(path string) Source {
+	return SourceFunc(func() (map[string]any, error) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		values, err := parseFile(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("config file %s: %w", path, err)
+		}
+		return values, nil
+	})
+}
+
+func Env(prefix string) Source {
+	return SourceFunc(func() (map[string]any, error) {
+		values := map[string]any{}
+		for _, kv := range os.Environ() {
+			key, value, _ := strings.Cut(kv, "=")
+			if !strings.HasPrefix(key, prefix) {
+				continue
+			}
+			values[envKey(strings.TrimPrefix(key, prefix))] = value
+		}
+		return values, nil
+	})
+}
+
+func Args(args []string) Source {
+	return SourceFunc(func() (map[string]any, error) {
+		values := map[string]any{}
+		for _, arg := range args {
+			if !strings.HasPrefix(arg, "--") {
+				continue
+			}
+			key, value, ok := strings.Cut(strings.TrimPrefix(arg, "--"), "=")
+			if !ok {
+				continue
+			}
+			values[key] = value
+		}
+		return values, nil
+	})
+}
+
+func parseFile(data string) (map[string]any, error) {
+	values := map[string]any{}
+	var sections []yamlSection
+	for i, line := range strings.Split(data, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		trimmed = strings.TrimPrefix(trimmed, "export ")
+
+		eq, colon := strings.Index(trimmed, "="), strings.Index(trimmed, ":")
+		if eq > 0 && (colon < 0 || eq < colon) {
+			values[envKey(strings.TrimSpace(trimmed[:eq]))] = unquoteValue(trimmed[eq+1:])
+			continue
+		}
+		if colon <= 0 {
+			return nil, fmt.Errorf("line %d: expected key=value or key: value, got %q", i+1, trimmed)
+		}
+
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		for len(sections) > 0 && sections[len(sections)-1].indent >= indent {
+			sections = sections[:len(sections)-1]
+		}
+		key := strings.TrimSpace(trimmed[:colon])
+		value := strings.TrimSpace(trimmed[colon+1:])
+		if value == "" {
+			sections = append(sections, yamlSection{indent: indent, key: key})
+			continue
+		}
+		path := make([]string, 0, len(sections)+1)
+		for _, s := range sections {
+			path = append(path, s.key)
+		}
+		values[strings.Join(append(path, key), ".")] = unquoteValue(value)
+	}
+	return values, nil
+}
+
+func envKey(key string) string {
+	return strings.ReplaceAll(strings.ToLower(key), "_", ".")
+}
+
+func unquoteValue(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	if len(value) >= 2 && value[0] == '[' && value[len(value)-1] == ']' {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
@@ -0,0 +1,118 @@
+package context
+
+import (
+	stdcontext "context"
+	"errors"
+	"reflect"
+)
+
+type beanList []any
+
+var ErrFrozen = errors.New("context is frozen")
+
+func DepAll[T any]() *Dependency[[]T] {
+	return DepAllScoped[T](DefaultScope)
+}
+
+func DepAllScoped[T any](scope string) *Dependency[[]T] {
+	return &Dependency[[]T]{request: newDependencyRequest(scope, (*T)(nil), true, true)}
+}
+
+func Freeze() {
+	GetContext().Freeze()
+}
+
+func AskAll[T any]() []T {
+	return mustValue(resolveValue[[]T](<-GetContext().AskAll((*T)(nil))))
+}
+
+func AskAllScoped[T any](scope string) []T {
+	return mustValue(resolveValue[[]T](<-GetContext().AskAllScoped(scope, (*T)(nil))))
+}
+
+func AskAllCtx[T any](ctx stdcontext.Context) ([]T, error) {
+	return AskAllScopedCtx[T](ctx, DefaultScope)
+}
+
+func AskAllScopedCtx[T any](ctx stdcontext.Context, scope string) ([]T, error) {
+	c := GetContext()
+	return awaitValue[[]T](ctx, c, scope, (*T)(nil), c.AskAllScoped(scope, (*T)(nil)))
+}
+
+func resolveList[T any](values beanList) (T, error) {
+	var zero T
+	list := reflect.MakeSlice(reflect.TypeOf(zero), 0, len(values))
+	for _, value := range values {
+		resolved, err := resolveValue[any](value)
+		if err != nil {
+			return zero, err
+		}
+		list = reflect.Append(list, reflect.ValueOf(resolved))
+	}
+	return list.Interface().(T), nil
+}
+
+func (m *memoryContext) Freeze() {
+	m.lock.Lock()
+	defer m.unlock()
+	m.frozen = true
+	m.notifyAll()
+}
+
+func (m *memoryContext) AskAll(t any) chan interface{} {
+	return m.AskAllScoped(DefaultScope, t)
+}
+
+func (m *memoryContext) AskAllScoped(s string, t any) chan interface{} {
+	if reflect.TypeOf(t).Elem().Kind() != reflect.Interface {
+		panic("unexpected type, interface type expected")
+	}
+
+	m.lock.Lock()
+	defer m.unlock()
+
+	waiter := newAskRequest(s, t, true)
+	waiter.all = true
+	m.appendAllWaiter(s, t, waiter)
+	return waiter.Waiter
+}
+
+func (m *memoryContext) appendAllWaiter(s string, t any, waiter *dependencyRequest) {
+	addWaiter(m.allRequests, s, t, waiter)
+	m.notifyAll()
+}
+
+func (m *memoryContext) notifyAll() {
+	if !m.frozen {
+		return
+	}
+	for s, scope := range m.allRequests {
+		for t, waiters := range scope {
+			values, ok := m.collectAll(s, reflect.Zero(t).Interface())
+			if !ok {
+				continue
+			}
+			for _, w := range waiters {
+				m.deliver(w, values)
+			}
+			delete(scope, t)
+		}
+	}
+}
+
+func (m *memoryContext) collectAll(s string, t any) (beanList, bool) {
+	targets := m.dependencyTargets(&dependencyRequest{Type: t, Scope: s, toInterface: true, all: true})
+	sortRegistrations(targets)
+	for _, target := range targets {
+		m.startLazy(target)
+	}
+	values := beanList{}
+	for _, target := range targets {
+		value, ok := m.storage[s][reflect.TypeOf(target.typ)]
+		if !ok {
+			return nil, false
+		}
+		values = append(values, m.view(target, t, value))
+	}
+	return values, true
+}
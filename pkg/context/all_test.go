@@ -0,0 +1,111 @@
+package context
+
+import (
+	stdcontext "context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type healthChecker interface {
+	Check() string
+}
+
+type dbChecker struct{}
+
+type cacheChecker struct{}
+
+type healthAggregator struct {
+	checkers []healthChecker
+}
+
+func (*dbChecker) Check() string {
+	return "db"
+}
+
+func (*cacheChecker) Check() string {
+	return "cache"
+}
+
+func checkNames(checkers []healthChecker) []string {
+	names := []string{}
+	for _, c := range checkers {
+		names = append(names, c.Check())
+	}
+	return names
+}
+
+func TestMemoryContext_AskAll(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	checkersDep := DepAll[healthChecker]()
+	Reg(func() *healthAggregator {
+		return &healthAggregator{checkers: ResolveDep[[]healthChecker](checkersDep)}
+	}, checkersDep)
+	Reg(func() *dbChecker {
+		return &dbChecker{}
+	})
+	RegPrototype(func() *cacheChecker {
+		return &cacheChecker{}
+	})
+	Reg(func() *firstIndependentStruct {
+		return &firstIndependentStruct{"firstTestString"}
+	})
+
+	ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := AskAllCtx[healthChecker](ctx); !errors.Is(err, stdcontext.DeadlineExceeded) {
+		t.Fatalf("Expected AskAll to wait for Freeze, got %v", err)
+	}
+
+	Freeze()
+	expected := []string{"db", "cache"}
+	if names := checkNames(AskAll[healthChecker]()); !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected %v, got %v", expected, names)
+	}
+	if names := checkNames(Ask[*healthAggregator]().checkers); !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected %v, got %v", expected, names)
+	}
+	if checkers := AskAllScoped[healthChecker]("other"); len(checkers) != 0 {
+		t.Errorf("Expected no checkers in other scope, got %v", checkers)
+	}
+}
+
+func TestMemoryContext_AskAll_Primary(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	checkersDep := DepAll[healthChecker]()
+	Reg(func() *healthAggregator {
+		return &healthAggregator{checkers: checkersDep.Get()}
+	}, checkersDep)
+	Reg(func() *dbChecker {
+		return &dbChecker{}
+	})
+	Reg(func() *cacheChecker {
+		return &cacheChecker{}
+	}, Primary())
+	Freeze()
+
+	expected := []string{"db", "cache"}
+	if names := checkNames(AskAll[healthChecker]()); !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected primary not to narrow AskAll to %v, got %v", expected, names)
+	}
+	if names := checkNames(Ask[*healthAggregator]().checkers); !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected primary not to narrow DepAll to %v, got %v", expected, names)
+	}
+	if name := AskInterface[healthChecker]().Check(); name != "cache" {
+		t.Errorf("Expected AskInterface to pick the primary, got %v", name)
+	}
+}
+
+func TestMemoryContext_AskAll_RegAfterFreeze(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	Freeze()
+	defer func() {
+		if err, ok := recover().(error); !ok || !errors.Is(err, ErrFrozen) {
+			t.Errorf("Expected panic with %v", ErrFrozen)
+		}
+	}()
+	Reg(func() *dbChecker {
+		return &dbChecker{}
+	})
+}
@@ -0,0 +1,136 @@
+package context
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestChildContext_ParentFallback(t *testing.T) {
+	parent := NewMemoryContext()
+	useContext(t, parent)
+	Reg(func() *firstIndependentStruct {
+		return &firstIndependentStruct{"parentFirst"}
+	})
+	Reg(func() *secondIndependentStruct {
+		return &secondIndependentStruct{"parentSecond"}
+	})
+
+	SetContext(NewChildContext(parent))
+	Reg(func() *secondIndependentStruct {
+		return &secondIndependentStruct{"childSecond"}
+	})
+	firstDep := Dep[*firstIndependentStruct]()
+	secondDep := Dep[*secondIndependentStruct]()
+	Reg(func() *dependentStruct {
+		return &dependentStruct{
+			firstDep:  ResolveDep[*firstIndependentStruct](firstDep),
+			secondDep: ResolveDep[*secondIndependentStruct](secondDep),
+		}
+	}, firstDep, secondDep)
+
+	if result := AskInterface[testInterface]().TestFunc(); result != "parentFirst childSecond" {
+		t.Errorf("Expected child bean to override parent bean, got %v", result)
+	}
+	if val := Ask[*secondIndependentStruct]().val; val != "childSecond" {
+		t.Errorf("Expected child bean, got %v", val)
+	}
+
+	SetContext(parent)
+	if val := Ask[*secondIndependentStruct]().val; val != "parentSecond" {
+		t.Errorf("Expected parent bean to stay untouched, got %v", val)
+	}
+}
+
+func TestChildContext_WaiterSatisfiedByParent(t *testing.T) {
+	parent := NewMemoryContext()
+	child := NewChildContext(parent)
+	useContext(t, child)
+
+	waiter := child.Ask((**firstIndependentStruct)(nil))
+	interfaceWaiter := child.AskInterface((*testInterface)(nil))
+
+	SetContext(parent)
+	Reg(func() *firstIndependentStruct {
+		return &firstIndependentStruct{"parentFirst"}
+	})
+	Reg(func() *dependentStruct {
+		return &dependentStruct{firstDep: &firstIndependentStruct{"a"}, secondDep: &secondIndependentStruct{"b"}}
+	})
+
+	if val := (<-waiter).(*firstIndependentStruct).val; val != "parentFirst" {
+		t.Errorf("Expected parent bean, got %v", val)
+	}
+	if result := (<-interfaceWaiter).(testInterface).TestFunc(); result != "a b" {
+		t.Errorf("Expected parent bean, got %v", result)
+	}
+	if unresolved := parent.GetUnresolvedRequests(); len(unresolved) != 0 {
+		t.Errorf("Expected no unresolved requests in parent, got %v", len(unresolved))
+	}
+}
+
+func TestChildContext_WaiterSatisfiedByChild(t *testing.T) {
+	parent := NewMemoryContext()
+	child := NewChildContext(parent)
+	useContext(t, child)
+
+	waiter := child.Ask((**firstIndependentStruct)(nil))
+	Reg(func() *firstIndependentStruct {
+		return &firstIndependentStruct{"childFirst"}
+	})
+	if val := (<-waiter).(*firstIndependentStruct).val; val != "childFirst" {
+		t.Errorf("Expected child bean, got %v", val)
+	}
+
+	SetContext(parent)
+	Reg(func() *firstIndependentStruct {
+		return &firstIndependentStruct{"parentFirst"}
+	})
+	Ask[*firstIndependentStruct]()
+	select {
+	case value := <-waiter:
+		t.Errorf("Expected single delivery, got %v", value)
+	default:
+	}
+}
+
+func TestChildContext_ExportGraph_ParentDependency(t *testing.T) {
+	parent := NewMemoryContext()
+	useContext(t, parent)
+	Reg(func() *firstIndependentStruct {
+		return &firstIndependentStruct{"parentFirst"}
+	})
+
+	SetContext(NewChildContext(parent))
+	firstDep := Dep[*firstIndependentStruct]()
+	missingDep := Dep[*missingStruct]()
+	Reg(func() *dependentStruct {
+		return &dependentStruct{firstDep: ResolveDep[*firstIndependentStruct](firstDep)}
+	}, firstDep)
+	Reg(func() *secondIndependentStruct {
+		return &secondIndependentStruct{}
+	}, missingDep)
+	Ask[*dependentStruct]()
+
+	buf := &bytes.Buffer{}
+	if err := ExportGraph(GraphJSON, buf); err != nil {
+		t.Fatal(err)
+	}
+	g := &graph{}
+	if err := json.Unmarshal(buf.Bytes(), g); err != nil {
+		t.Fatal(err)
+	}
+	states := map[string]BeanState{}
+	for _, n := range g.Nodes {
+		states[n.Type] = n.State
+	}
+	if state := states["*context.firstIndependentStruct"]; state != StateParent {
+		t.Errorf("Expected the parent dependency to be marked %q, got %q", StateParent, state)
+	}
+	if state := states["*context.missingStruct"]; state != StateMissing {
+		t.Errorf("Expected the unregistered dependency to be marked %q, got %q", StateMissing, state)
+	}
+	if state := states["*context.dependentStruct"]; state != StateReady {
+		t.Errorf("Expected the dependent bean to be ready, got %q", state)
+	}
+}
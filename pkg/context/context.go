@@ -1,7 +1,12 @@
 package context
 
 import (
+	stdcontext "context"
+	"io"
+	"reflect"
+	"runtime/debug"
 	"sync"
+	"sync/atomic"
 )
 
 const DefaultScope = ""
@@ -12,44 +17,125 @@ var (
 )
 
 type (
+	factory interface {
+		produce() (any, error)
+		onProduce(hook func(any) (any, error))
+	}
+
 	prototype[T any] struct {
-		constructor func() T
+		constructor func() (T, error)
+		scope       string
+		callSite    string
+		produced    func(any) (any, error)
+	}
+
+	beanFailure struct {
+		err error
 	}
 
 	dependencyRequest struct {
 		Type        any
 		Waiter      chan any
 		Scope       string
+		done        chan struct{}
+		value       any
 		toInterface bool
+		all         bool
+		delivered   atomic.Bool
+		owner       *registration
+		context     Context
+		callSite    string
 	}
 
 	Context interface {
-		Reg(interfaceNil any, constructor func() interface{}, request ...*dependencyRequest)
+		Reg(interfaceNil any, constructor func() interface{}, options ...RegOption)
 		Ask(interfaceNil any) chan interface{}
 		AskInterface(interfaceNil any) chan interface{}
 
-		RegScoped(scope string, interfaceNil any, constructor func() interface{}, request ...*dependencyRequest)
+		RegScoped(scope string, interfaceNil any, constructor func() interface{}, options ...RegOption)
 		AskScoped(scope string, interfaceNil any) chan interface{}
 		AskInterfaceScoped(scope string, interfaceNil any) chan interface{}
 
+		AskAll(interfaceNil any) chan interface{}
+		AskAllScoped(scope string, interfaceNil any) chan interface{}
+		Freeze()
+
+		CancelAsk(scope string, interfaceNil any, waiter chan interface{})
+		Shutdown(ctx stdcontext.Context) error
+		ExportGraph(format GraphFormat, w io.Writer) error
+		Subscribe(l Listener) func()
+		StartupReport() *TimingReport
+
+		RegDecoratorScoped(scope string, interfaceNil any, decorator func(interface{}) interface{})
+		RegInterfaceDecoratorScoped(scope string, interfaceNil any, decorator func(interface{}) interface{})
+		RegInterceptorScoped(scope string, interfaceNil any, interceptor Interceptor)
+
+		HasBean(scope string, interfaceNil any) bool
+		ActiveProfiles() []string
+		SetProfiles(profiles ...string)
+
 		GetUnresolvedRequests() []*dependencyRequest
+		Unresolved() []UnresolvedDependency
+		Validate() error
+		WaitReady(ctx stdcontext.Context) error
 	}
 )
 
-func DepInterface[T any]() *dependencyRequest {
-	return &dependencyRequest{(*T)(nil), make(chan any, 1), DefaultScope, true}
+func (p *prototype[T]) produce() (produced any, err error) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		produced, err = nil, &BeanConstructionError{
+			Type:     reflect.TypeOf((*T)(nil)).Elem(),
+			Scope:    p.scope,
+			Value:    r,
+			Stack:    debug.Stack(),
+			CallSite: p.callSite,
+		}
+	}()
+	value, err := p.constructor()
+	if err != nil {
+		return nil, &BeanError{Type: reflect.TypeOf((*T)(nil)).Elem(), Scope: p.scope, Err: err}
+	}
+	if p.produced == nil {
+		return value, nil
+	}
+	if produced, err = p.produced(value); err != nil {
+		return nil, &BeanError{Type: reflect.TypeOf((*T)(nil)).Elem(), Scope: p.scope, Err: err}
+	}
+	return produced, nil
+}
+
+func (p *prototype[T]) onProduce(hook func(any) (any, error)) {
+	p.produced = hook
+}
+
+func (r *dependencyRequest) isDelivered() bool {
+	return r.delivered.Load()
 }
 
-func DepInterfaceScoped[T any](scope string) *dependencyRequest {
-	return &dependencyRequest{(*T)(nil), make(chan any, 1), scope, true}
+func newAskRequest(scope string, t any, toInterface bool) *dependencyRequest {
+	r := newDependencyRequest(scope, t, toInterface, false)
+	r.callSite = callSite()
+	return r
 }
 
-func Dep[T any]() *dependencyRequest {
-	return &dependencyRequest{(*T)(nil), make(chan any, 1), DefaultScope, false}
+func DepInterface[T any]() *Dependency[T] {
+	return DepInterfaceScoped[T](DefaultScope)
 }
 
-func DepScoped[T any](scope string) *dependencyRequest {
-	return &dependencyRequest{(*T)(nil), make(chan any, 1), scope, false}
+func DepInterfaceScoped[T any](scope string) *Dependency[T] {
+	return newDependency[T](scope, true, false)
+}
+
+func Dep[T any]() *Dependency[T] {
+	return DepScoped[T](DefaultScope)
+}
+
+func DepScoped[T any](scope string) *Dependency[T] {
+	return newDependency[T](scope, false, false)
 }
 
 func SetContext(context Context) {
@@ -64,68 +150,141 @@ func GetContext() Context {
 	return CurrentContext
 }
 
+func Shutdown(ctx stdcontext.Context) error {
+	return GetContext().Shutdown(ctx)
+}
+
+func ExportGraph(format GraphFormat, w io.Writer) error {
+	return GetContext().ExportGraph(format, w)
+}
+
 func Ask[T any]() T {
-	value := (<-GetContext().Ask((*T)(nil)))
-	if prototype, ok := value.(*prototype[T]); ok {
-		return prototype.constructor()
-	}
-	return value.(T)
+	return mustValue(resolveValue[T](<-GetContext().Ask((*T)(nil))))
 }
 
 func AskInterface[T any]() T {
-	value := (<-GetContext().AskInterface((*T)(nil)))
-	if prototype, ok := value.(*prototype[T]); ok {
-		return prototype.constructor()
-	}
-	return value.(T)
+	return mustValue(resolveValue[T](<-GetContext().AskInterface((*T)(nil))))
+}
+
+func AskCtx[T any](ctx stdcontext.Context) (T, error) {
+	return AskScopedCtx[T](ctx, DefaultScope)
+}
+
+func AskInterfaceCtx[T any](ctx stdcontext.Context) (T, error) {
+	return AskInterfaceScopedCtx[T](ctx, DefaultScope)
+}
+
+func Reg[T any](constructor func() T, options ...RegOption) {
+	GetContext().Reg((*T)(nil), typeToAnyFunc[T](constructor), options...)
+}
+
+func RegLazy[T any](constructor func() T, options ...RegOption) {
+	Reg[T](constructor, append(options, Lazy())...)
+}
+
+func RegE[T any](constructor func() (T, error), options ...RegOption) {
+	GetContext().Reg((*T)(nil), typeToAnyFuncE[T](constructor), options...)
 }
 
-func Reg[T any](constructor func() T, request ...*dependencyRequest) {
-	GetContext().Reg((*T)(nil), typeToAnyFunc[T](constructor), request...)
+func RegPrototype[T any](constructor func() T, options ...RegOption) {
+	RegPrototypeE[T](withNilError(constructor), options...)
 }
 
-func RegPrototype[T any](constructor func() T, request ...*dependencyRequest) {
+func RegPrototypeE[T any](constructor func() (T, error), options ...RegOption) {
+	site := callSite()
 	GetContext().Reg((*T)(nil), func() interface{} {
-		return &prototype[T]{constructor: constructor}
-	}, request...)
+		return &prototype[T]{constructor: constructor, scope: DefaultScope, callSite: site}
+	}, append(options, asPrototype())...)
 }
 
 func AskScoped[T any](scope string) T {
-	value := (<-GetContext().AskScoped(scope, (*T)(nil)))
-	if prototype, ok := value.(*prototype[T]); ok {
-		return prototype.constructor()
-	}
-	return value.(T)
+	return mustValue(resolveValue[T](<-GetContext().AskScoped(scope, (*T)(nil))))
 }
 
 func AskInterfaceScoped[T any](scope string) T {
-	value := (<-GetContext().AskInterfaceScoped(scope, (*T)(nil)))
-	if prototype, ok := value.(*prototype[T]); ok {
-		return prototype.constructor()
-	}
-	return value.(T)
+	return mustValue(resolveValue[T](<-GetContext().AskInterfaceScoped(scope, (*T)(nil))))
+}
+
+func AskScopedCtx[T any](ctx stdcontext.Context, scope string) (T, error) {
+	c := GetContext()
+	return awaitValue[T](ctx, c, scope, (*T)(nil), c.AskScoped(scope, (*T)(nil)))
+}
+
+func AskInterfaceScopedCtx[T any](ctx stdcontext.Context, scope string) (T, error) {
+	c := GetContext()
+	return awaitValue[T](ctx, c, scope, (*T)(nil), c.AskInterfaceScoped(scope, (*T)(nil)))
 }
 
-func RegScoped[T any](scope string, constructor func() T, request ...*dependencyRequest) {
-	GetContext().RegScoped(scope, (*T)(nil), typeToAnyFunc[T](constructor), request...)
+func RegScoped[T any](scope string, constructor func() T, options ...RegOption) {
+	GetContext().RegScoped(scope, (*T)(nil), typeToAnyFunc[T](constructor), options...)
 }
 
-func RegPrototypeScoped[T any](scope string, constructor func() T, request ...*dependencyRequest) {
+func RegLazyScoped[T any](scope string, constructor func() T, options ...RegOption) {
+	RegScoped[T](scope, constructor, append(options, Lazy())...)
+}
+
+func RegScopedE[T any](scope string, constructor func() (T, error), options ...RegOption) {
+	GetContext().RegScoped(scope, (*T)(nil), typeToAnyFuncE[T](constructor), options...)
+}
+
+func RegPrototypeScoped[T any](scope string, constructor func() T, options ...RegOption) {
+	RegPrototypeScopedE[T](scope, withNilError(constructor), options...)
+}
+
+func RegPrototypeScopedE[T any](scope string, constructor func() (T, error), options ...RegOption) {
+	site := callSite()
 	GetContext().RegScoped(scope, (*T)(nil), func() interface{} {
-		return &prototype[T]{constructor: constructor}
-	}, request...)
+		return &prototype[T]{constructor: constructor, scope: scope, callSite: site}
+	}, append(options, asPrototype())...)
 }
 
-func ResolveDep[T any](dep *dependencyRequest) T {
-	rawVal := <-dep.Waiter
-	go func() {
-		dep.Waiter <- rawVal
-	}()
+func ResolveDep[T any](dep *Dependency[T]) T {
+	return dep.Get()
+}
 
-	if prototype, ok := rawVal.(*prototype[T]); ok {
-		return prototype.constructor()
+func ResolveDepCtx[T any](ctx stdcontext.Context, dep *Dependency[T]) (T, error) {
+	return dep.GetCtx(ctx)
+}
+
+func awaitValue[T any](ctx stdcontext.Context, c Context, scope string, t any, waiter chan interface{}) (T, error) {
+	select {
+	case value := <-waiter:
+		return resolveValue[T](value)
+	case <-ctx.Done():
+	}
+	err := newUnresolvedError(c, scope, t, ctx.Err())
+	c.CancelAsk(scope, t, waiter)
+	select {
+	case value := <-waiter:
+		return resolveValue[T](value)
+	default:
+		var zero T
+		return zero, err
+	}
+}
+
+func resolveValue[T any](value any) (T, error) {
+	var zero T
+	switch v := value.(type) {
+	case *beanFailure:
+		return zero, v.err
+	case beanList:
+		return resolveList[T](v)
+	case factory:
+		produced, err := v.produce()
+		if err != nil {
+			return zero, err
+		}
+		return produced.(T), nil
+	}
+	return value.(T), nil
+}
+
+func mustValue[T any](value T, err error) T {
+	if err != nil {
+		panic(err)
 	}
-	return (rawVal).(T)
+	return value
 }
 
 func typeToAnyFunc[T any](f func() T) func() any {
@@ -133,3 +292,19 @@ func typeToAnyFunc[T any](f func() T) func() any {
 		return f()
 	}
 }
+
+func typeToAnyFuncE[T any](f func() (T, error)) func() any {
+	return func() any {
+		value, err := f()
+		if err != nil {
+			return &beanFailure{err}
+		}
+		return value
+	}
+}
+
+func withNilError[T any](f func() T) func() (T, error) {
+	return func() (T, error) {
+		return f(), nil
+	}
+}
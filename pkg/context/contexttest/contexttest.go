@@ -0,0 +1,93 @@
+package contexttest
+
+import (
+	stdcontext "context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/catmorte/go-ioc/pkg/context"
+)
+
+var (
+	Timeout  = time.Second
+	isolated sync.Map
+)
+
+func Isolate(t testing.TB) context.Context {
+	t.Helper()
+	if c, ok := isolated.Load(t); ok {
+		return c.(context.Context)
+	}
+	previous := context.GetContext()
+	c := context.NewChildContext(previous, context.WithDuplicatePolicy(context.DuplicateLastWins), context.WithLazyRegistrations())
+	isolated.Store(t, c)
+	context.SetContext(c)
+	context.ReplayModules()
+	t.Cleanup(func() {
+		if context.GetContext() != c {
+			t.Errorf("contexttest: the global context was replaced by another test, Isolate can't be used with t.Parallel")
+		}
+		context.SetContext(previous)
+		isolated.Delete(t)
+	})
+	return c
+}
+
+func Override[T any](t testing.TB, fake T) {
+	t.Helper()
+	OverrideScoped[T](t, context.DefaultScope, fake)
+}
+
+func OverrideScoped[T any](t testing.TB, scope string, fake T) {
+	t.Helper()
+	Isolate(t)
+	context.RegScoped[T](scope, func() T {
+		return fake
+	})
+}
+
+func OverrideInterface[T any](t testing.TB, fake T) {
+	t.Helper()
+	OverrideInterfaceScoped[T](t, context.DefaultScope, fake)
+}
+
+func OverrideInterfaceScoped[T any](t testing.TB, scope string, fake T) {
+	t.Helper()
+	Isolate(t)
+	context.RegScoped[T](scope, func() T {
+		return fake
+	}, context.Primary())
+}
+
+func Ask[T any](t testing.TB) T {
+	t.Helper()
+	return AskScoped[T](t, context.DefaultScope)
+}
+
+func AskScoped[T any](t testing.TB, scope string) T {
+	t.Helper()
+	ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), Timeout)
+	defer cancel()
+	value, err := context.AskScopedCtx[T](ctx, scope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return value
+}
+
+func AskInterface[T any](t testing.TB) T {
+	t.Helper()
+	return AskInterfaceScoped[T](t, context.DefaultScope)
+}
+
+func AskInterfaceScoped[T any](t testing.TB, scope string) T {
+	t.Helper()
+	ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), Timeout)
+	defer cancel()
+	value, err := context.AskInterfaceScopedCtx[T](ctx, scope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return value
+}
@@ -0,0 +1,211 @@
+package contexttest
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/catmorte/go-ioc/pkg/context"
+)
+
+type greeter interface {
+	Greet() string
+}
+
+type realGreeter struct{}
+
+type fakeGreeter struct{}
+
+type store struct {
+	name string
+}
+
+type service struct {
+	store   *store
+	greeter greeter
+}
+
+type missing struct{}
+
+type consumer struct {
+	store *store
+}
+
+type fatalRecorder struct {
+	testing.TB
+	message string
+}
+
+type cleanupRecorder struct {
+	testing.TB
+	cleanups []func()
+	errors   []string
+}
+
+func (*realGreeter) Greet() string {
+	return "real"
+}
+
+func (*fakeGreeter) Greet() string {
+	return "fake"
+}
+
+func (r *fatalRecorder) Fatal(args ...any) {
+	r.message = fmt.Sprint(args...)
+	panic(r)
+}
+
+func (r *cleanupRecorder) Cleanup(f func()) {
+	r.cleanups = append(r.cleanups, f)
+}
+
+func (r *cleanupRecorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *cleanupRecorder) cleanup() {
+	for i := len(r.cleanups) - 1; i >= 0; i-- {
+		r.cleanups[i]()
+	}
+}
+
+func init() {
+	context.SetContext(context.NewMemoryContext())
+	context.Reg(func() *realGreeter {
+		return &realGreeter{}
+	})
+	context.RegModule(func() {
+		context.Reg(func() *store {
+			return &store{"real"}
+		})
+		storeDep := context.Dep[*store]()
+		context.Reg(func() *consumer {
+			return &consumer{store: context.ResolveDep[*store](storeDep)}
+		}, storeDep)
+	})
+}
+
+func regService() {
+	storeDep := context.Dep[*store]()
+	greeterDep := context.DepInterface[greeter]()
+	context.Reg(func() *service {
+		return &service{
+			store:   context.ResolveDep[*store](storeDep),
+			greeter: context.ResolveDep[greeter](greeterDep),
+		}
+	}, storeDep, greeterDep)
+}
+
+func TestOverride(t *testing.T) {
+	base := context.GetContext()
+	t.Run("overridden", func(t *testing.T) {
+		Override(t, &store{"fake"})
+		OverrideInterface[greeter](t, &fakeGreeter{})
+		regService()
+
+		s := Ask[*service](t)
+		if s.store.name != "fake" || s.greeter.Greet() != "fake" {
+			t.Errorf("Expected fakes to be injected, got %v %v", s.store.name, s.greeter.Greet())
+		}
+	})
+	if context.GetContext() != base {
+		t.Fatalf("Expected context to be restored")
+	}
+
+	t.Run("isolated", func(t *testing.T) {
+		Isolate(t)
+		regService()
+		s := Ask[*service](t)
+		if s.store.name != "real" || s.greeter.Greet() != "real" {
+			t.Errorf("Expected real beans to be injected, got %v %v", s.store.name, s.greeter.Greet())
+		}
+		if greeting := AskInterface[greeter](t).Greet(); greeting != "real" {
+			t.Errorf("Expected real greeter, got %v", greeting)
+		}
+	})
+}
+
+func TestAsk_Timeout(t *testing.T) {
+	Isolate(t)
+	timeout := Timeout
+	Timeout = 10 * time.Millisecond
+	defer func() {
+		Timeout = timeout
+	}()
+
+	recorder := &fatalRecorder{TB: t}
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer func() {
+			recover()
+		}()
+		AskScoped[*missing](recorder, "missing")
+	}()
+	wg.Wait()
+	if !strings.Contains(recorder.message, `still missing: [*contexttest.missing in scope "missing"]`) {
+		t.Errorf("Expected unresolved report, got %q", recorder.message)
+	}
+}
+
+func TestOverride_Twice(t *testing.T) {
+	Override(t, &store{"first"})
+	if name := Ask[*store](t).name; name != "first" {
+		t.Fatalf("Expected first fake, got %v", name)
+	}
+	Override(t, &store{"second"})
+	if name := Ask[*store](t).name; name != "second" {
+		t.Errorf("Expected last override to win, got %v", name)
+	}
+}
+
+func TestOverride_InitRegistered(t *testing.T) {
+	base := context.GetContext()
+	Override(t, &store{"fake"})
+	if name := Ask[*consumer](t).store.name; name != "fake" {
+		t.Errorf("Expected an init-registered consumer to get the fake, got %v", name)
+	}
+	if name := (<-base.AskScoped(context.DefaultScope, (**consumer)(nil))).(*consumer).store.name; name != "real" {
+		t.Errorf("Expected the base context to keep the real store, got %v", name)
+	}
+}
+
+func TestIsolate_GlobalContext(t *testing.T) {
+	base := context.GetContext()
+	c := Isolate(t)
+	if context.GetContext() != c {
+		t.Fatalf("Expected Isolate to replace the global context")
+	}
+	t.Run("nested", func(t *testing.T) {
+		nested := Isolate(t)
+		if context.GetContext() != nested || nested == c {
+			t.Errorf("Expected a nested test to get its own child context")
+		}
+	})
+	if context.GetContext() != c {
+		t.Errorf("Expected the nested test to restore its parent's context")
+	}
+	if c == base {
+		t.Errorf("Expected an isolated child context")
+	}
+}
+
+func TestIsolate_Parallel(t *testing.T) {
+	base := context.GetContext()
+	defer context.SetContext(base)
+	first, second := &cleanupRecorder{TB: t}, &cleanupRecorder{TB: t}
+	Isolate(first)
+	Isolate(second)
+
+	first.cleanup()
+	second.cleanup()
+	if len(first.errors) != 1 || !strings.Contains(first.errors[0], "t.Parallel") {
+		t.Errorf("Expected the first test to report the replaced context, got %v", first.errors)
+	}
+	if len(second.errors) != 1 {
+		t.Errorf("Expected the second test to report the replaced context, got %v", second.errors)
+	}
+}
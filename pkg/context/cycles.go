@@ -0,0 +1,111 @@
+package context
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+type (
+	BeanRef struct {
+		Type  reflect.Type
+		Scope string
+	}
+
+	CycleError struct {
+		Path []BeanRef
+	}
+)
+
+func (b BeanRef) String() string {
+	return fmt.Sprintf("%v in scope %q", b.Type, b.Scope)
+}
+
+func (e *CycleError) Error() string {
+	path := make([]string, 0, len(e.Path))
+	for _, b := range e.Path {
+		path = append(path, b.String())
+	}
+	return "dependency cycle detected: " + strings.Join(path, " -> ")
+}
+
+func newBeanRef(scope string, t any) BeanRef {
+	return BeanRef{Type: reflect.TypeOf(t).Elem(), Scope: scope}
+}
+
+func (m *memoryContext) dependencyTargets(r *dependencyRequest) []*registration {
+	if !r.toInterface {
+		if reg, ok := m.registrations[r.Scope][reflect.TypeOf(r.Type)]; ok {
+			return []*registration{reg}
+		}
+		return nil
+	}
+	if !r.all {
+		if reg, err := m.resolveInterface(r.Scope, r.Type); err == nil && reg != nil {
+			return []*registration{reg}
+		}
+	}
+	return m.implementations(r.Scope, r.Type)
+}
+
+func (m *memoryContext) implementations(s string, iface any) []*registration {
+	return m.index.lookup(s, reflect.TypeOf(iface), m.registrations[s])
+}
+
+func (m *memoryContext) resolveInterface(s string, iface any) (*registration, error) {
+	candidates := m.implementations(s, iface)
+	if len(candidates) <= 1 {
+		if len(candidates) == 0 {
+			return nil, nil
+		}
+		return candidates[0], nil
+	}
+	var primaries []*registration
+	for _, reg := range candidates {
+		if reg.primary {
+			primaries = append(primaries, reg)
+		}
+	}
+	if len(primaries) == 1 {
+		return primaries[0], nil
+	}
+	ambiguity := &AmbiguousBeanError{Interface: reflect.TypeOf(iface).Elem(), Scope: s}
+	for _, reg := range candidates {
+		ambiguity.Candidates = append(ambiguity.Candidates, newBeanRef(reg.scope, reg.typ))
+	}
+	return nil, ambiguity
+}
+
+func (m *memoryContext) findCycle(start *registration) *CycleError {
+	visited := map[*registration]bool{}
+	var path []*registration
+	var visit func(reg *registration) bool
+	visit = func(reg *registration) bool {
+		path = append(path, reg)
+		for _, r := range reg.requests {
+			for _, target := range m.dependencyTargets(r) {
+				if target == start {
+					path = append(path, target)
+					return true
+				}
+				if visited[target] {
+					continue
+				}
+				visited[target] = true
+				if visit(target) {
+					return true
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		return false
+	}
+	if !visit(start) {
+		return nil
+	}
+	cycle := &CycleError{Path: make([]BeanRef, 0, len(path))}
+	for _, reg := range path {
+		cycle.Path = append(cycle.Path, newBeanRef(reg.scope, reg.typ))
+	}
+	return cycle
+}
@@ -0,0 +1,78 @@
+package context
+
+import (
+	stdcontext "context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type cycleNamer interface {
+	Name() string
+}
+
+type cycleFirst struct {
+	second *cycleSecond
+}
+
+type cycleSecond struct {
+	namer cycleNamer
+}
+
+func (c *cycleFirst) Name() string {
+	return "first"
+}
+
+func regCycle() {
+	namerDep := DepInterface[cycleNamer]()
+	Reg(func() *cycleSecond {
+		return &cycleSecond{namer: ResolveDep[cycleNamer](namerDep)}
+	}, namerDep)
+
+	secondDep := Dep[*cycleSecond]()
+	Reg(func() *cycleFirst {
+		return &cycleFirst{second: ResolveDep[*cycleSecond](secondDep)}
+	}, secondDep)
+}
+
+func TestMemoryContext_Cycle(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	regCycle()
+
+	ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), time.Second)
+	defer cancel()
+	_, err := AskCtx[*cycleSecond](ctx)
+	var cycleErr *CycleError
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("Expected CycleError, got %v", err)
+	}
+	expected := `dependency cycle detected: *context.cycleFirst in scope "" -> *context.cycleSecond in scope "" -> *context.cycleFirst in scope ""`
+	if cycleErr.Error() != expected {
+		t.Errorf("Expected %q, got %q", expected, cycleErr.Error())
+	}
+}
+
+func TestMemoryContext_Cycle_Strict(t *testing.T) {
+	useContext(t, NewMemoryContext(WithStrictCycles()))
+	defer func() {
+		if _, ok := recover().(*CycleError); !ok {
+			t.Errorf("Expected panic with CycleError")
+		}
+	}()
+	regCycle()
+}
+
+func TestMemoryContext_Cycle_Self(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	selfDep := Dep[*firstIndependentStruct]()
+	Reg(func() *firstIndependentStruct {
+		return ResolveDep[*firstIndependentStruct](selfDep)
+	}, selfDep)
+
+	ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), time.Second)
+	defer cancel()
+	var cycleErr *CycleError
+	if _, err := AskCtx[*firstIndependentStruct](ctx); !errors.As(err, &cycleErr) || len(cycleErr.Path) != 2 {
+		t.Errorf("Expected CycleError, got %v", err)
+	}
+}
@@ -0,0 +1,154 @@
+package context
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+type (
+	decorator struct {
+		scope       string
+		typ         any
+		toInterface bool
+		decorate    func(any) any
+	}
+
+	interfaceView struct {
+		source   any
+		decorate func(any) (any, error)
+		once     sync.Once
+		value    any
+		err      error
+	}
+)
+
+var ErrBeanStarted = errors.New("bean has already started")
+
+func RegDecorator[T any](decorate func(T) T) {
+	RegDecoratorScoped[T](DefaultScope, decorate)
+}
+
+func RegDecoratorScoped[T any](scope string, decorate func(T) T) {
+	GetContext().RegDecoratorScoped(scope, (*T)(nil), typeToAnyDecorator(decorate))
+}
+
+func RegInterfaceDecorator[I any](decorate func(I) I) {
+	RegInterfaceDecoratorScoped[I](DefaultScope, decorate)
+}
+
+func RegInterfaceDecoratorScoped[I any](scope string, decorate func(I) I) {
+	GetContext().RegInterfaceDecoratorScoped(scope, (*I)(nil), typeToAnyDecorator(decorate))
+}
+
+func (m *memoryContext) RegDecoratorScoped(s string, t any, decorate func(interface{}) interface{}) {
+	m.lock.Lock()
+	defer m.unlock()
+	m.addDecorator(&decorator{scope: s, typ: t, decorate: decorate})
+}
+
+func (m *memoryContext) RegInterfaceDecoratorScoped(s string, t any, decorate func(interface{}) interface{}) {
+	if reflect.TypeOf(t).Elem().Kind() != reflect.Interface {
+		panic("unexpected type, interface type expected")
+	}
+
+	m.lock.Lock()
+	defer m.unlock()
+	m.addDecorator(&decorator{scope: s, typ: t, toInterface: true, decorate: decorate})
+}
+
+func (m *memoryContext) addDecorator(d *decorator) {
+	targets := m.dependencyTargets(&dependencyRequest{Type: d.typ, Scope: d.scope, toInterface: d.toInterface, all: true})
+	for _, reg := range targets {
+		if reg.started && !reg.prototype {
+			panic(fmt.Errorf("%w: can't decorate %v", ErrBeanStarted, newBeanRef(reg.scope, reg.typ)))
+		}
+	}
+	m.decorators = append(m.decorators, d)
+}
+
+func (d *decorator) matches(reg *registration) bool {
+	return d.scope == reg.scope && d.typ == reg.typ
+}
+
+func (d *decorator) matchesView(reg *registration, t any) bool {
+	return d.toInterface && d.scope == reg.scope && d.typ == t && implements(reflect.TypeOf(reg.typ), reflect.TypeOf(t))
+}
+
+func (m *memoryContext) decorate(reg *registration, value any) (any, error) {
+	m.lock.RLock()
+	decorators := make([]*decorator, 0, len(m.decorators))
+	for _, d := range m.decorators {
+		if d.matches(reg) {
+			decorators = append(decorators, d)
+		}
+	}
+	m.lock.RUnlock()
+
+	regType := reflect.TypeOf(reg.typ).Elem()
+	for _, d := range decorators {
+		value = d.decorate(value)
+		if value == nil || !reflect.TypeOf(value).AssignableTo(regType) {
+			return nil, fmt.Errorf("decorator for %v returned %T, which is not assignable to %v", reflect.TypeOf(d.typ).Elem(), value, regType)
+		}
+	}
+	return value, nil
+}
+
+func typeToAnyDecorator[T any](decorate func(T) T) func(any) any {
+	return func(value any) any {
+		return decorate(value.(T))
+	}
+}
+
+func (m *memoryContext) view(reg *registration, t any, value any) any {
+	if _, failed := value.(*beanFailure); failed || reg.typ == t {
+		return value
+	}
+	decorators := []*decorator{}
+	for _, d := range m.decorators {
+		if d.matchesView(reg, t) {
+			decorators = append(decorators, d)
+		}
+	}
+	if len(decorators) == 0 {
+		return value
+	}
+	if v, ok := reg.views[reflect.TypeOf(t)]; ok {
+		return v
+	}
+	v := &interfaceView{source: value, decorate: func(value any) (any, error) {
+		for _, d := range decorators {
+			if value = d.decorate(value); value == nil {
+				return nil, &BeanError{
+					Type:  reflect.TypeOf(reg.typ).Elem(),
+					Scope: reg.scope,
+					Err:   fmt.Errorf("decorator for %v returned nil", reflect.TypeOf(t).Elem()),
+				}
+			}
+		}
+		return value, nil
+	}}
+	if reg.views == nil {
+		reg.views = map[reflect.Type]*interfaceView{}
+	}
+	reg.views[reflect.TypeOf(t)] = v
+	return v
+}
+
+func (v *interfaceView) produce() (any, error) {
+	if f, ok := v.source.(factory); ok {
+		value, err := f.produce()
+		if err != nil {
+			return nil, err
+		}
+		return v.decorate(value)
+	}
+	v.once.Do(func() {
+		v.value, v.err = v.decorate(v.source)
+	})
+	return v.value, v.err
+}
+
+func (v *interfaceView) onProduce(func(any) (any, error)) {}
@@ -0,0 +1,196 @@
+package context
+
+import (
+	stdcontext "context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type greeter interface {
+	Greet() string
+}
+
+type plainGreeter struct {
+	greeting string
+}
+
+type loudGreeter struct {
+	greeter
+}
+
+func (g *plainGreeter) Greet() string {
+	return g.greeting
+}
+
+func (g *loudGreeter) Greet() string {
+	return strings.ToUpper(g.greeter.Greet()) + "!"
+}
+
+func TestMemoryContext_RegDecorator(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	RegDecorator(func(g *plainGreeter) *plainGreeter {
+		g.greeting += "+first"
+		return g
+	})
+	RegInterfaceDecorator(func(g greeter) greeter {
+		g.(*plainGreeter).greeting += "+interface"
+		return g
+	})
+	RegDecorator(func(g *plainGreeter) *plainGreeter {
+		g.greeting += "+second"
+		return g
+	})
+	RegDecoratorScoped("other", func(g *plainGreeter) *plainGreeter {
+		g.greeting += "+other"
+		return g
+	})
+	Reg(func() *plainGreeter {
+		return &plainGreeter{"hello"}
+	})
+
+	if greeting := Ask[*plainGreeter]().Greet(); greeting != "hello+first+second" {
+		t.Errorf("Expected type decorators in registration order, got %v", greeting)
+	}
+	if greeting := AskInterface[greeter]().Greet(); greeting != "hello+first+second+interface" {
+		t.Errorf("Expected interface decorator on interface resolution, got %v", greeting)
+	}
+}
+
+func TestMemoryContext_RegInterfaceDecorator_Wrap(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	RegInterfaceDecorator(func(g greeter) greeter {
+		return &loudGreeter{g}
+	})
+	Reg(func() greeter {
+		return &plainGreeter{"hello"}
+	})
+	RegInterfaceDecoratorScoped("concrete", func(g greeter) greeter {
+		return &loudGreeter{g}
+	})
+	pending := AskInterfaceScopedAsync[greeter]("concrete")
+	RegScoped("concrete", func() *plainGreeter {
+		return &plainGreeter{"concrete"}
+	})
+	RegPrototypeScoped("prototype", func() *plainGreeter {
+		return &plainGreeter{"prototype"}
+	})
+	RegInterfaceDecoratorScoped("prototype", func(g greeter) greeter {
+		return &loudGreeter{g}
+	})
+
+	if greeting := Ask[greeter]().Greet(); greeting != "HELLO!" {
+		t.Errorf("Expected decorated greeting, got %v", greeting)
+	}
+	if greeting := AskScoped[*plainGreeter]("concrete").Greet(); greeting != "concrete" {
+		t.Errorf("Expected concrete resolution to skip the interface decorator, got %v", greeting)
+	}
+	ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), time.Second)
+	defer cancel()
+	wrapped, err := pending.Await(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if greeting := wrapped.Greet(); greeting != "CONCRETE!" {
+		t.Errorf("Expected wrapped greeting for a pending interface request, got %v", greeting)
+	}
+	if again := AskInterfaceScoped[greeter]("concrete"); again != wrapped {
+		t.Errorf("Expected the same wrapper for every interface resolution of a singleton")
+	}
+	Freeze()
+	if all := AskAllScoped[greeter]("concrete"); len(all) != 1 || all[0] != wrapped {
+		t.Errorf("Expected AskAll to return the wrapper, got %v", all)
+	}
+	first, second := AskInterfaceScoped[greeter]("prototype"), AskInterfaceScoped[greeter]("prototype")
+	if first.Greet() != "PROTOTYPE!" || first == second {
+		t.Errorf("Expected a new wrapper for every prototype, got %v", first.Greet())
+	}
+}
+
+func TestMemoryContext_RegInterfaceDecorator_Nil(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	RegInterfaceDecorator(func(g greeter) greeter {
+		return nil
+	})
+	Reg(func() *plainGreeter {
+		return &plainGreeter{"hello"}
+	})
+
+	ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), time.Second)
+	defer cancel()
+	_, err := AskInterfaceCtx[greeter](ctx)
+	var beanErr *BeanError
+	if !errors.As(err, &beanErr) || !strings.Contains(err.Error(), "returned nil") {
+		t.Errorf("Expected decorator error, got %v", err)
+	}
+}
+
+func TestMemoryContext_RegDecorator_Prototype(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	decorated := 0
+	RegDecorator(func(v *firstIndependentStruct) *firstIndependentStruct {
+		decorated++
+		v.val += "+decorated"
+		return v
+	})
+	RegPrototype(func() *firstIndependentStruct {
+		return &firstIndependentStruct{"value"}
+	}, TrackPrototypes())
+
+	for i := 0; i < 3; i++ {
+		if val := Ask[*firstIndependentStruct]().val; val != "value+decorated" {
+			t.Errorf("Expected decorated prototype, got %v", val)
+		}
+	}
+	if decorated != 3 {
+		t.Errorf("Expected decorator to run for every prototype, got %v", decorated)
+	}
+}
+
+func TestMemoryContext_RegDecorator_AfterStart(t *testing.T) {
+	for _, pause := range []time.Duration{0, 5 * time.Millisecond} {
+		useContext(t, NewMemoryContext())
+		Reg(func() *plainGreeter {
+			return &plainGreeter{"hello"}
+		})
+		time.Sleep(pause)
+		for _, register := range []func(){
+			func() {
+				RegDecorator(func(g *plainGreeter) *plainGreeter {
+					return g
+				})
+			},
+			func() {
+				RegInterfaceDecorator(func(g greeter) greeter {
+					return g
+				})
+			},
+		} {
+			func() {
+				defer func() {
+					if err, ok := recover().(error); !ok || !errors.Is(err, ErrBeanStarted) {
+						t.Errorf("Expected panic with %v (pause %v), got %v", ErrBeanStarted, pause, err)
+					}
+				}()
+				register()
+			}()
+		}
+	}
+}
+
+func TestMemoryContext_RegDecorator_Lazy(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	RegLazy(func() *plainGreeter {
+		return &plainGreeter{"hello"}
+	})
+	time.Sleep(5 * time.Millisecond)
+	RegDecorator(func(g *plainGreeter) *plainGreeter {
+		g.greeting += "+late"
+		return g
+	})
+
+	if greeting := Ask[*plainGreeter]().Greet(); greeting != "hello+late" {
+		t.Errorf("Expected a decorator registered before a lazy bean starts to apply, got %v", greeting)
+	}
+}
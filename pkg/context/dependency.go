@@ -0,0 +1,80 @@
+package context
+
+import (
+	stdcontext "context"
+	"sync"
+)
+
+type Dependency[T any] struct {
+	request *dependencyRequest
+	once    sync.Once
+	value   T
+	err     error
+}
+
+func newDependency[T any](scope string, toInterface bool, all bool) *Dependency[T] {
+	return &Dependency[T]{request: newDependencyRequest(scope, (*T)(nil), toInterface, all)}
+}
+
+func newDependencyRequest(scope string, t any, toInterface bool, all bool) *dependencyRequest {
+	return &dependencyRequest{Type: t, Waiter: make(chan any, 1), done: make(chan struct{}), Scope: scope, toInterface: toInterface, all: all}
+}
+
+func (d *Dependency[T]) applyReg(options *regOptions) {
+	d.request.applyReg(options)
+}
+
+func (d *Dependency[T]) Done() <-chan struct{} {
+	return d.request.done
+}
+
+func (d *Dependency[T]) Get() T {
+	<-d.request.done
+	return mustValue(d.resolve())
+}
+
+func (d *Dependency[T]) GetCtx(ctx stdcontext.Context) (T, error) {
+	select {
+	case <-d.request.done:
+		return d.resolve()
+	case <-ctx.Done():
+		var zero T
+		return zero, newUnresolvedError(d.request.owningContext(), d.request.Scope, d.request.Type, ctx.Err())
+	}
+}
+
+func (d *Dependency[T]) Err() error {
+	select {
+	case <-d.request.done:
+		if _, ok := d.request.value.(factory); ok {
+			return nil
+		}
+		_, err := d.resolve()
+		return err
+	default:
+		return nil
+	}
+}
+
+func (d *Dependency[T]) resolve() (T, error) {
+	if _, ok := d.request.value.(factory); ok {
+		return resolveValue[T](d.request.value)
+	}
+	d.once.Do(func() {
+		d.value, d.err = resolveValue[T](d.request.value)
+	})
+	return d.value, d.err
+}
+
+func (r *dependencyRequest) owningContext() Context {
+	if r.context != nil {
+		return r.context
+	}
+	return GetContext()
+}
+
+func (r *dependencyRequest) fulfil(value any) {
+	r.value = value
+	close(r.done)
+	r.Waiter <- value
+}
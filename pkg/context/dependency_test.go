@@ -0,0 +1,140 @@
+package context
+
+import (
+	stdcontext "context"
+	"errors"
+	"reflect"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDependency_Get(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	firstDep := Dep[*firstIndependentStruct]()
+	Reg(func() *dependentStruct {
+		return &dependentStruct{firstDep: firstDep.Get()}
+	}, firstDep)
+	select {
+	case <-firstDep.Done():
+		t.Fatalf("Expected dependency to be pending before registration")
+	default:
+	}
+	Reg(func() *firstIndependentStruct {
+		return &firstIndependentStruct{"first"}
+	})
+	Ask[*dependentStruct]()
+
+	<-firstDep.Done()
+	goroutines := runtime.NumGoroutine()
+	first := firstDep.Get()
+	for i := 0; i < 1000; i++ {
+		if firstDep.Get() != first {
+			t.Fatalf("Expected the same resolved value on every read")
+		}
+	}
+	if leaked := runtime.NumGoroutine() - goroutines; leaked > 0 {
+		t.Errorf("Expected reads not to start goroutines, got %d more", leaked)
+	}
+}
+
+func TestDependency_ConcurrentReaders(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	firstDep := Dep[*firstIndependentStruct]()
+	Reg(func() *dependentStruct {
+		return &dependentStruct{}
+	}, firstDep)
+
+	results := make(chan *firstIndependentStruct, 100)
+	wg := sync.WaitGroup{}
+	wg.Add(cap(results))
+	for i := 0; i < cap(results); i++ {
+		go func() {
+			defer wg.Done()
+			results <- firstDep.Get()
+		}()
+	}
+	Reg(func() *firstIndependentStruct {
+		return &firstIndependentStruct{"first"}
+	})
+	wg.Wait()
+	close(results)
+
+	expected := Ask[*firstIndependentStruct]()
+	for result := range results {
+		if result != expected {
+			t.Errorf("Expected every reader to get %p, got %p", expected, result)
+		}
+	}
+}
+
+func TestDependency_GetCtx(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	missingDep := Dep[*missingStruct]()
+	Reg(func() *dependentStruct {
+		return &dependentStruct{}
+	}, missingDep)
+
+	ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := missingDep.GetCtx(ctx)
+	var unresolvedErr *UnresolvedError
+	if !errors.Is(err, stdcontext.DeadlineExceeded) || !errors.As(err, &unresolvedErr) {
+		t.Errorf("Expected UnresolvedError wrapping the deadline, got %v", err)
+	}
+}
+
+func TestDependency_GetCtx_ChildContext(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	child := NewChildContext(NewMemoryContext())
+	missingDep := Dep[*missingStruct]()
+	child.Reg((*dependentStruct)(nil), func() interface{} {
+		return &dependentStruct{}
+	}, missingDep)
+
+	ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := missingDep.GetCtx(ctx)
+	var unresolvedErr *UnresolvedError
+	if !errors.As(err, &unresolvedErr) {
+		t.Fatalf("Expected UnresolvedError, got %v", err)
+	}
+	if expected := []string{`*context.missingStruct in scope ""`}; !reflect.DeepEqual(unresolvedErr.Missing, expected) {
+		t.Errorf("Expected missing beans of the owning context %v, got %v", expected, unresolvedErr.Missing)
+	}
+}
+
+func TestDependency_Failure(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	rootErr := errors.New("boom")
+	firstDep := Dep[*firstIndependentStruct]()
+	Reg(func() *dependentStruct {
+		return &dependentStruct{}
+	}, firstDep)
+	RegE(func() (*firstIndependentStruct, error) {
+		return nil, rootErr
+	})
+
+	if _, err := firstDep.GetCtx(stdcontext.Background()); !errors.Is(err, rootErr) {
+		t.Errorf("Expected dependency failure, got %v", err)
+	}
+}
+
+func BenchmarkDependency_Get(b *testing.B) {
+	previous := GetContext()
+	SetContext(NewMemoryContext())
+	defer SetContext(previous)
+	firstDep := Dep[*firstIndependentStruct]()
+	Reg(func() *dependentStruct {
+		return &dependentStruct{}
+	}, firstDep)
+	Reg(func() *firstIndependentStruct {
+		return &firstIndependentStruct{"first"}
+	})
+	firstDep.Get()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		firstDep.Get()
+	}
+}
@@ -0,0 +1,99 @@
+package context
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+type DuplicatePolicy int
+
+const (
+	DuplicateError DuplicatePolicy = iota
+	DuplicateFirstWins
+	DuplicateLastWins
+)
+
+var (
+	ErrDuplicateBean = errors.New("bean is already registered")
+	errReplaced      = errors.New("bean registration was replaced")
+)
+
+type DuplicateBeanError struct {
+	Type             reflect.Type
+	Scope            string
+	CallSite         string
+	PreviousCallSite string
+	Err              error
+}
+
+func (e *DuplicateBeanError) Error() string {
+	return fmt.Sprintf("bean %v in scope %q registered at %s: %v (previous registration at %s)", e.Type, e.Scope, e.CallSite, e.Err, e.PreviousCallSite)
+}
+
+func (e *DuplicateBeanError) Unwrap() error {
+	return e.Err
+}
+
+func WithDuplicatePolicy(policy DuplicatePolicy) MemoryContextOption {
+	return func(m *memoryContext) {
+		m.duplicatePolicy = policy
+	}
+}
+
+func Replace[T any](constructor func() T, options ...RegOption) {
+	Reg[T](constructor, append(options, asReplacement())...)
+}
+
+func ReplaceE[T any](constructor func() (T, error), options ...RegOption) {
+	RegE[T](constructor, append(options, asReplacement())...)
+}
+
+func ReplaceScoped[T any](scope string, constructor func() T, options ...RegOption) {
+	RegScoped[T](scope, constructor, append(options, asReplacement())...)
+}
+
+func asReplacement() RegOption {
+	return regOptionFunc(func(options *regOptions) {
+		options.replace = true
+	})
+}
+
+func (m *memoryContext) lockedDuplicate(old *registration, reg *registration, replace bool) bool {
+	switch {
+	case replace || m.duplicatePolicy == DuplicateLastWins:
+		m.lockedRemove(old)
+		reg.order = old.order
+		return true
+	case m.duplicatePolicy == DuplicateFirstWins:
+		return false
+	}
+	panic(&DuplicateBeanError{
+		Type:             reflect.TypeOf(reg.typ).Elem(),
+		Scope:            reg.scope,
+		CallSite:         reg.callSite,
+		PreviousCallSite: old.callSite,
+		Err:              ErrDuplicateBean,
+	})
+}
+
+func (m *memoryContext) lockedRemove(reg *registration) {
+	delete(m.registrations[reg.scope], reflect.TypeOf(reg.typ))
+	m.index.remove(reg)
+	delete(m.storage[reg.scope], reflect.TypeOf(reg.typ))
+	for _, r := range reg.requests {
+		for _, requests := range []map[string]map[reflect.Type][]*dependencyRequest{m.requests, m.interfaceRequests, m.allRequests} {
+			removeWaiter(requests, r.Scope, r.Waiter)
+		}
+		if m.parent != nil {
+			m.parent.CancelAsk(r.Scope, r.Type, r.Waiter)
+		}
+		if r.delivered.CompareAndSwap(false, true) {
+			r.fulfil(&beanFailure{errReplaced})
+		}
+	}
+}
+
+func (m *memoryContext) isRegistered(reg *registration) bool {
+	return m.registrations[reg.scope][reflect.TypeOf(reg.typ)] == reg
+}
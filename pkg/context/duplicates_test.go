@@ -0,0 +1,93 @@
+package context
+
+import (
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestMemoryContext_DuplicateError(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	Reg(func() *plainGreeter {
+		return &plainGreeter{"first"}
+	})
+	defer func() {
+		err, ok := recover().(error)
+		if !ok || !errors.Is(err, ErrDuplicateBean) {
+			t.Fatalf("Expected panic with %v, got %v", ErrDuplicateBean, err)
+		}
+		var duplicateErr *DuplicateBeanError
+		if !errors.As(err, &duplicateErr) {
+			t.Fatalf("Expected DuplicateBeanError, got %T", err)
+		}
+		if !strings.Contains(duplicateErr.CallSite, "duplicates_test.go") || !strings.Contains(duplicateErr.PreviousCallSite, "duplicates_test.go") || duplicateErr.CallSite == duplicateErr.PreviousCallSite {
+			t.Errorf("Expected both call sites, got %q", err.Error())
+		}
+		if greeting := Ask[*plainGreeter]().Greet(); greeting != "first" {
+			t.Errorf("Expected first registration to be kept, got %v", greeting)
+		}
+	}()
+	Reg(func() *plainGreeter {
+		return &plainGreeter{"second"}
+	})
+}
+
+func TestMemoryContext_DuplicateFirstWins(t *testing.T) {
+	useContext(t, NewMemoryContext(WithDuplicatePolicy(DuplicateFirstWins)))
+	Reg(func() *plainGreeter {
+		return &plainGreeter{"first"}
+	})
+	Reg(func() *plainGreeter {
+		return &plainGreeter{"second"}
+	})
+
+	if greeting := Ask[*plainGreeter]().Greet(); greeting != "first" {
+		t.Errorf("Expected first registration to win, got %v", greeting)
+	}
+}
+
+func TestMemoryContext_DuplicateLastWins(t *testing.T) {
+	useContext(t, NewMemoryContext(WithDuplicatePolicy(DuplicateLastWins)))
+	Reg(func() *plainGreeter {
+		return &plainGreeter{"first"}
+	})
+	if greeting := Ask[*plainGreeter]().Greet(); greeting != "first" {
+		t.Fatalf("Expected first registration before override, got %v", greeting)
+	}
+	Reg(func() *plainGreeter {
+		return &plainGreeter{"second"}
+	})
+
+	if greeting := Ask[*plainGreeter]().Greet(); greeting != "second" {
+		t.Errorf("Expected last registration to win, got %v", greeting)
+	}
+}
+
+func TestMemoryContext_Replace(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	var constructed atomic.Bool
+	Reg(func() *plainGreeter {
+		constructed.Store(true)
+		return &plainGreeter{"original"}
+	}, Dep[*missingStruct]())
+	Replace(func() *plainGreeter {
+		return &plainGreeter{"replacement"}
+	})
+	Replace(func() *loudGreeter {
+		return &loudGreeter{&plainGreeter{"new"}}
+	})
+
+	if greeting := Ask[*plainGreeter]().Greet(); greeting != "replacement" {
+		t.Errorf("Expected replacement, got %v", greeting)
+	}
+	if greeting := Ask[*loudGreeter]().Greet(); greeting != "NEW!" {
+		t.Errorf("Expected Replace of a new type to register it, got %v", greeting)
+	}
+	if constructed.Load() {
+		t.Errorf("Expected replaced constructor not to run")
+	}
+	if err := GetContext().Validate(); err != nil {
+		t.Errorf("Expected replaced dependencies to be dropped, got %v", err)
+	}
+}
@@ -0,0 +1,109 @@
+package context
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+var packagePath = reflect.TypeOf(memoryContext{}).PkgPath()
+
+type UnresolvedError struct {
+	Type    reflect.Type
+	Scope   string
+	Missing []string
+	Err     error
+}
+
+func (e *UnresolvedError) Error() string {
+	return fmt.Sprintf("bean %v in scope %q is not resolved: %v; still missing: [%s]", e.Type, e.Scope, e.Err, strings.Join(e.Missing, ", "))
+}
+
+func (e *UnresolvedError) Unwrap() error {
+	return e.Err
+}
+
+type BeanError struct {
+	Type  reflect.Type
+	Scope string
+	Err   error
+}
+
+func (e *BeanError) Error() string {
+	return fmt.Sprintf("bean %v in scope %q failed: %v", e.Type, e.Scope, e.Err)
+}
+
+func (e *BeanError) Unwrap() error {
+	return e.Err
+}
+
+type BeanConstructionError struct {
+	Type     reflect.Type
+	Scope    string
+	Value    any
+	Stack    []byte
+	CallSite string
+}
+
+func (e *BeanConstructionError) Error() string {
+	return fmt.Sprintf("bean %v in scope %q registered at %s panicked: %v", e.Type, e.Scope, e.CallSite, e.Value)
+}
+
+func (e *BeanConstructionError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+type AmbiguousBeanError struct {
+	Interface  reflect.Type
+	Scope      string
+	Candidates []BeanRef
+}
+
+func (e *AmbiguousBeanError) Error() string {
+	candidates := make([]string, 0, len(e.Candidates))
+	for _, c := range e.Candidates {
+		candidates = append(candidates, c.Type.String())
+	}
+	return fmt.Sprintf("interface %v in scope %q is ambiguous: %s implement it and none is primary", e.Interface, e.Scope, strings.Join(candidates, ", "))
+}
+
+func newBeanFailure(scope string, t any, err error) *beanFailure {
+	return &beanFailure{&BeanError{Type: reflect.TypeOf(t).Elem(), Scope: scope, Err: err}}
+}
+
+func newUnresolvedError(c Context, scope string, t any, err error) *UnresolvedError {
+	seen := map[string]bool{}
+	missing := []string{}
+	for _, u := range c.Unresolved() {
+		name := BeanRef{Type: u.Type, Scope: u.Scope}.String()
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		missing = append(missing, name)
+	}
+	sort.Strings(missing)
+	return &UnresolvedError{
+		Type:    reflect.TypeOf(t).Elem(),
+		Scope:   scope,
+		Missing: missing,
+		Err:     err,
+	}
+}
+
+func callSite() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, packagePath) || strings.HasSuffix(frame.File, "_test.go") {
+			return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
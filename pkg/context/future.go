@@ -0,0 +1,116 @@
+package context
+
+import (
+	stdcontext "context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+type (
+	Future[T any] struct {
+		context Context
+		request *dependencyRequest
+		once    sync.Once
+		value   T
+		err     error
+	}
+
+	Awaitable interface {
+		Done() <-chan struct{}
+		Err() error
+	}
+)
+
+func AskAsync[T any]() *Future[T] {
+	return AskScopedAsync[T](DefaultScope)
+}
+
+func AskScopedAsync[T any](scope string) *Future[T] {
+	return newFuture[T](GetContext(), scope, false)
+}
+
+func AskInterfaceAsync[T any]() *Future[T] {
+	return AskInterfaceScopedAsync[T](DefaultScope)
+}
+
+func AskInterfaceScopedAsync[T any](scope string) *Future[T] {
+	if reflect.TypeOf((*T)(nil)).Elem().Kind() != reflect.Interface {
+		panic("unexpected type, interface type expected")
+	}
+	return newFuture[T](GetContext(), scope, true)
+}
+
+func AwaitAll(ctx stdcontext.Context, futures ...Awaitable) error {
+	var errs []error
+	for _, f := range futures {
+		select {
+		case <-f.Done():
+			errs = append(errs, f.Err())
+		case <-ctx.Done():
+			return errors.Join(append(errs, ctx.Err())...)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func newFuture[T any](c Context, scope string, toInterface bool) *Future[T] {
+	attacher, ok := c.(requestAttacher)
+	if !ok {
+		panic(fmt.Sprintf("unsupported context %T", c))
+	}
+	r := newAskRequest(scope, (*T)(nil), toInterface)
+	attacher.lockedAttachRequest(r)
+	return &Future[T]{context: c, request: r}
+}
+
+func (f *Future[T]) resolve() (T, error) {
+	f.once.Do(func() {
+		f.value, f.err = resolveValue[T](f.request.value)
+	})
+	return f.value, f.err
+}
+
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.request.done
+}
+
+func (f *Future[T]) Err() error {
+	select {
+	case <-f.request.done:
+		_, err := f.resolve()
+		return err
+	default:
+		return nil
+	}
+}
+
+func (f *Future[T]) Await(ctx stdcontext.Context) (T, error) {
+	select {
+	case <-f.request.done:
+		return f.resolve()
+	case <-ctx.Done():
+		var zero T
+		err := newUnresolvedError(f.context, f.request.Scope, f.request.Type, ctx.Err())
+		f.Cancel()
+		return zero, err
+	}
+}
+
+func (f *Future[T]) Then(fn func(T)) *Future[T] {
+	go func() {
+		<-f.request.done
+		if value, err := f.resolve(); err == nil {
+			fn(value)
+		}
+	}()
+	return f
+}
+
+func (f *Future[T]) Cancel() {
+	f.context.CancelAsk(f.request.Scope, f.request.Type, f.request.Waiter)
+	if f.request.delivered.CompareAndSwap(false, true) {
+		f.request.fulfil(&beanFailure{newUnresolvedError(f.context, f.request.Scope, f.request.Type, stdcontext.Canceled)})
+	}
+}
@@ -0,0 +1,172 @@
+package context
+
+import (
+	stdcontext "context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestFuture_AskAsync(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	first := AskAsync[*firstIndependentStruct]()
+	greeter := AskInterfaceAsync[greeter]()
+	scoped := AskScopedAsync[*firstIndependentStruct]("scoped")
+
+	Reg(func() *firstIndependentStruct {
+		return &firstIndependentStruct{"first"}
+	})
+	Reg(func() *plainGreeter {
+		return &plainGreeter{"hello"}
+	})
+	RegScoped("scoped", func() *firstIndependentStruct {
+		return &firstIndependentStruct{"scoped"}
+	})
+
+	ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), time.Second)
+	defer cancel()
+	value, err := first.Await(ctx)
+	if err != nil || value.val != "first" {
+		t.Fatalf("Expected first bean, got %v %v", value, err)
+	}
+	if again, _ := first.Await(ctx); again != value {
+		t.Errorf("Expected Await to return the same value every time")
+	}
+	if g, err := greeter.Await(ctx); err != nil || g.Greet() != "hello" {
+		t.Errorf("Expected greeter, got %v %v", g, err)
+	}
+	if s, err := scoped.Await(ctx); err != nil || s.val != "scoped" {
+		t.Errorf("Expected scoped bean, got %v %v", s, err)
+	}
+}
+
+func TestFuture_Then(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	values := make(chan string, 1)
+	AskAsync[*firstIndependentStruct]().Then(func(v *firstIndependentStruct) {
+		values <- v.val
+	})
+	Reg(func() *firstIndependentStruct {
+		return &firstIndependentStruct{"first"}
+	})
+
+	select {
+	case v := <-values:
+		if v != "first" {
+			t.Errorf("Expected callback with first bean, got %v", v)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Expected callback to be called")
+	}
+}
+
+func TestFuture_AwaitTimeout(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	future := AskAsync[*firstIndependentStruct]()
+
+	ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := future.Await(ctx)
+	var unresolvedErr *UnresolvedError
+	if !errors.Is(err, stdcontext.DeadlineExceeded) || !errors.As(err, &unresolvedErr) {
+		t.Fatalf("Expected UnresolvedError wrapping the deadline, got %v", err)
+	}
+
+	if _, err := future.Await(stdcontext.Background()); !errors.Is(err, stdcontext.Canceled) {
+		t.Errorf("Expected a timed out Await to cancel the future, got %v", err)
+	}
+	if unresolved := GetContext().GetUnresolvedRequests(); len(unresolved) != 0 {
+		t.Errorf("Expected the timed out request to be removed, got %d", len(unresolved))
+	}
+}
+
+func TestFuture_AwaitTimeout_NoLeak(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	before := runtime.NumGoroutine()
+	for i := 0; i < 100; i++ {
+		ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), time.Millisecond)
+		AskAsync[*missingStruct]().Await(ctx)
+		cancel()
+	}
+
+	if after := runtime.NumGoroutine(); after > before+10 {
+		t.Errorf("Expected abandoned futures not to keep goroutines, got %d before and %d after", before, after)
+	}
+	if unresolved := GetContext().GetUnresolvedRequests(); len(unresolved) != 0 {
+		t.Errorf("Expected abandoned futures not to keep requests, got %d", len(unresolved))
+	}
+}
+
+func TestFuture_PrototypePanic(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	RegPrototype(func() *firstIndependentStruct {
+		panic("boom")
+	})
+
+	ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), time.Second)
+	defer cancel()
+	future := AskAsync[*firstIndependentStruct]()
+	_, err := future.Await(ctx)
+	var constructionErr *BeanConstructionError
+	if !errors.As(err, &constructionErr) || constructionErr.Value != "boom" {
+		t.Fatalf("Expected BeanConstructionError, got %v", err)
+	}
+	if !errors.As(future.Err(), &constructionErr) {
+		t.Errorf("Expected Err to report the panic, got %v", future.Err())
+	}
+}
+
+func TestFuture_Cancel(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	future := AskAsync[*missingStruct]()
+	future.Cancel()
+	future.Cancel()
+
+	if _, err := future.Await(stdcontext.Background()); !errors.Is(err, stdcontext.Canceled) {
+		t.Errorf("Expected cancelled future, got %v", err)
+	}
+	if unresolved := GetContext().GetUnresolvedRequests(); len(unresolved) != 0 {
+		t.Errorf("Expected cancelled request to be removed, got %d", len(unresolved))
+	}
+}
+
+func TestAwaitAll(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	rootErr := errors.New("boom")
+	first := AskAsync[*firstIndependentStruct]()
+	greeter := AskInterfaceAsync[greeter]()
+	secondDep := Dep[*secondIndependentStruct]()
+	Reg(func() *dependentStruct {
+		return &dependentStruct{secondDep: secondDep.Get()}
+	}, secondDep)
+
+	Reg(func() *firstIndependentStruct {
+		return &firstIndependentStruct{"first"}
+	})
+	Reg(func() *plainGreeter {
+		return &plainGreeter{"hello"}
+	})
+	Reg(func() *secondIndependentStruct {
+		return &secondIndependentStruct{"second"}
+	})
+	ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), time.Second)
+	defer cancel()
+	if err := AwaitAll(ctx, first, greeter, secondDep); err != nil {
+		t.Fatalf("Expected every future to resolve, got %v", err)
+	}
+
+	failed := AskScopedAsync[*firstIndependentStruct]("failed")
+	RegScopedE("failed", func() (*firstIndependentStruct, error) {
+		return nil, rootErr
+	})
+	if err := AwaitAll(ctx, first, failed); !errors.Is(err, rootErr) {
+		t.Errorf("Expected failure to be reported, got %v", err)
+	}
+
+	timeout, cancelTimeout := stdcontext.WithTimeout(stdcontext.Background(), 10*time.Millisecond)
+	defer cancelTimeout()
+	if err := AwaitAll(timeout, first, AskAsync[*missingStruct]()); !errors.Is(err, stdcontext.DeadlineExceeded) {
+		t.Errorf("Expected deadline error, got %v", err)
+	}
+}
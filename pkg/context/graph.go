@@ -0,0 +1,172 @@
+package context
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+type (
+	GraphFormat string
+	BeanState   string
+
+	graphNode struct {
+		ID       string    `json:"id"`
+		Type     string    `json:"type"`
+		Scope    string    `json:"scope"`
+		Strategy string    `json:"strategy,omitempty"`
+		State    BeanState `json:"state"`
+	}
+
+	graphEdge struct {
+		From      string `json:"from"`
+		To        string `json:"to"`
+		Interface bool   `json:"interface"`
+	}
+
+	graph struct {
+		Nodes []*graphNode `json:"nodes"`
+		Edges []*graphEdge `json:"edges"`
+	}
+)
+
+const (
+	GraphDOT     GraphFormat = "dot"
+	GraphMermaid GraphFormat = "mermaid"
+	GraphJSON    GraphFormat = "json"
+
+	StateLazy         BeanState = "lazy"
+	StateWaiting      BeanState = "waiting"
+	StateConstructing BeanState = "constructing"
+	StateReady        BeanState = "ready"
+	StateFailed       BeanState = "failed"
+	StateMissing      BeanState = "missing"
+	StateParent       BeanState = "parent"
+
+	strategySingleton = "singleton"
+	strategyPrototype = "prototype"
+)
+
+func (m *memoryContext) ExportGraph(format GraphFormat, w io.Writer) error {
+	g := m.snapshotGraph()
+	switch format {
+	case GraphDOT:
+		return writeDOT(g, w)
+	case GraphMermaid:
+		return writeMermaid(g, w)
+	case GraphJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(g)
+	}
+	return fmt.Errorf("unknown graph format %q", format)
+}
+
+func (m *memoryContext) snapshotGraph() *graph {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	var regs []*registration
+	for _, scope := range m.registrations {
+		for _, reg := range scope {
+			regs = append(regs, reg)
+		}
+	}
+	sortRegistrations(regs)
+
+	g := &graph{Nodes: []*graphNode{}, Edges: []*graphEdge{}}
+	ids := map[*registration]string{}
+	for _, reg := range regs {
+		strategy := strategySingleton
+		if reg.prototype {
+			strategy = strategyPrototype
+		}
+		ids[reg] = fmt.Sprintf("n%d", reg.order)
+		g.Nodes = append(g.Nodes, &graphNode{
+			ID:       ids[reg],
+			Type:     reflect.TypeOf(reg.typ).Elem().String(),
+			Scope:    reg.scope,
+			Strategy: strategy,
+			State:    reg.state,
+		})
+	}
+
+	external := map[BeanRef]string{}
+	for _, reg := range regs {
+		for _, r := range reg.requests {
+			targets := m.dependencyTargets(r)
+			for _, target := range targets {
+				g.Edges = append(g.Edges, &graphEdge{From: ids[reg], To: ids[target], Interface: r.toInterface})
+			}
+			if len(targets) > 0 {
+				continue
+			}
+			ref := newBeanRef(r.Scope, r.Type)
+			id, ok := external[ref]
+			if !ok {
+				state, prefix := StateMissing, "m"
+				if m.parent != nil && m.parent.lockedCanResolve(r) {
+					state, prefix = StateParent, "p"
+				}
+				id = fmt.Sprintf("%s%d", prefix, len(external)+1)
+				external[ref] = id
+				g.Nodes = append(g.Nodes, &graphNode{ID: id, Type: ref.Type.String(), Scope: ref.Scope, State: state})
+			}
+			g.Edges = append(g.Edges, &graphEdge{From: ids[reg], To: id, Interface: r.toInterface})
+		}
+	}
+	return g
+}
+
+func nodeLabel(n *graphNode, lineBreak string) string {
+	details := string(n.State)
+	if n.Strategy != "" {
+		details = n.Strategy + ", " + details
+	}
+	return strings.Join([]string{n.Type, fmt.Sprintf("scope: %q", n.Scope), details}, lineBreak)
+}
+
+func writeDOT(g *graph, w io.Writer) error {
+	b := &strings.Builder{}
+	b.WriteString("digraph beans {\n")
+	for _, n := range g.Nodes {
+		style := ""
+		switch n.State {
+		case StateMissing:
+			style = ", style=dashed"
+		case StateParent:
+			style = ", style=dotted"
+		}
+		fmt.Fprintf(b, "\t%s [label=%q%s];\n", n.ID, nodeLabel(n, "\n"), style)
+	}
+	for _, e := range g.Edges {
+		if e.Interface {
+			fmt.Fprintf(b, "\t%s -> %s [style=dashed, label=\"interface\"];\n", e.From, e.To)
+			continue
+		}
+		fmt.Fprintf(b, "\t%s -> %s;\n", e.From, e.To)
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeMermaid(g *graph, w io.Writer) error {
+	b := &strings.Builder{}
+	b.WriteString("graph TD\n")
+	for _, n := range g.Nodes {
+		label := strings.ReplaceAll(nodeLabel(n, "<br/>"), `"`, "#quot;")
+		fmt.Fprintf(b, "\t%s[\"%s\"]\n", n.ID, label)
+	}
+	for _, e := range g.Edges {
+		if e.Interface {
+			fmt.Fprintf(b, "\t%s -. interface .-> %s\n", e.From, e.To)
+			continue
+		}
+		fmt.Fprintf(b, "\t%s --> %s\n", e.From, e.To)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,99 @@
+package context
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+type graphService struct{}
+
+type graphRepo struct{}
+
+type graphNamer interface {
+	Name() string
+}
+
+type graphMissing struct{}
+
+func (*graphRepo) Name() string {
+	return "repo"
+}
+
+func regGraph(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	RegPrototype(func() *graphRepo {
+		return &graphRepo{}
+	})
+	repoDep := Dep[*graphRepo]()
+	namerDep := DepInterface[graphNamer]()
+	missingDep := DepScoped[*graphMissing]("other")
+	Reg(func() *graphService {
+		ResolveDep[*graphMissing](missingDep)
+		return &graphService{}
+	}, repoDep, namerDep, missingDep)
+	Ask[*graphRepo]()
+}
+
+func TestMemoryContext_ExportGraph_DOT(t *testing.T) {
+	regGraph(t)
+	b := &bytes.Buffer{}
+	if err := ExportGraph(GraphDOT, b); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	expected := `digraph beans {
+	n1 [label="*context.graphRepo\nscope: \"\"\nprototype, ready"];
+	n2 [label="*context.graphService\nscope: \"\"\nsingleton, waiting"];
+	m1 [label="*context.graphMissing\nscope: \"other\"\nmissing", style=dashed];
+	n2 -> n1;
+	n2 -> n1 [style=dashed, label="interface"];
+	n2 -> m1;
+}
+`
+	if b.String() != expected {
+		t.Errorf("Expected\n%s\ngot\n%s", expected, b.String())
+	}
+}
+
+func TestMemoryContext_ExportGraph_Mermaid(t *testing.T) {
+	regGraph(t)
+	b := &bytes.Buffer{}
+	if err := ExportGraph(GraphMermaid, b); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	for _, line := range []string{
+		`n1["*context.graphRepo<br/>scope: #quot;#quot;<br/>prototype, ready"]`,
+		`n2 -. interface .-> n1`,
+		`n2 --> m1`,
+	} {
+		if !strings.Contains(b.String(), line) {
+			t.Errorf("Expected %q in\n%s", line, b.String())
+		}
+	}
+}
+
+func TestMemoryContext_ExportGraph_JSON(t *testing.T) {
+	regGraph(t)
+	b := &bytes.Buffer{}
+	if err := ExportGraph(GraphJSON, b); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	g := &graph{}
+	if err := json.Unmarshal(b.Bytes(), g); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if len(g.Nodes) != 3 || len(g.Edges) != 3 {
+		t.Fatalf("Expected 3 nodes and 3 edges, got %v", b.String())
+	}
+	if g.Nodes[1].State != StateWaiting || g.Nodes[1].Strategy != "singleton" || !g.Edges[1].Interface {
+		t.Errorf("Unexpected graph %v", b.String())
+	}
+}
+
+func TestMemoryContext_ExportGraph_UnknownFormat(t *testing.T) {
+	regGraph(t)
+	if err := ExportGraph("svg", &bytes.Buffer{}); err == nil {
+		t.Errorf("Expected error for unknown format")
+	}
+}
@@ -0,0 +1,114 @@
+package context
+
+import (
+	stdcontext "context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMemoryContext_RegLazy(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	var constructed atomic.Int32
+	RegLazy(func() *firstIndependentStruct {
+		constructed.Add(1)
+		return &firstIndependentStruct{"firstTestString"}
+	})
+
+	time.Sleep(10 * time.Millisecond)
+	if constructed.Load() != 0 {
+		t.Fatalf("Expected lazy bean not to be constructed before it is asked")
+	}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if val := Ask[*firstIndependentStruct]().val; val != "firstTestString" {
+				t.Errorf("Expected value %v, got %v", "firstTestString", val)
+			}
+		}()
+	}
+	wg.Wait()
+	if constructed.Load() != 1 {
+		t.Errorf("Expected lazy bean to be constructed once, got %v", constructed.Load())
+	}
+}
+
+func TestMemoryContext_RegLazy_Dependencies(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	var constructed atomic.Int32
+	RegLazy(func() *firstIndependentStruct {
+		constructed.Add(1)
+		return &firstIndependentStruct{"firstTestString"}
+	})
+	RegLazyScoped("lazy", func() *secondIndependentStruct {
+		constructed.Add(1)
+		return &secondIndependentStruct{"secondTestString"}
+	})
+
+	firstDep := Dep[*firstIndependentStruct]()
+	secondDep := DepScoped[*secondIndependentStruct]("lazy")
+	RegLazy(func() *dependentStruct {
+		constructed.Add(1)
+		return &dependentStruct{
+			firstDep:  ResolveDep[*firstIndependentStruct](firstDep),
+			secondDep: ResolveDep[*secondIndependentStruct](secondDep),
+		}
+	}, firstDep, secondDep)
+
+	time.Sleep(10 * time.Millisecond)
+	if constructed.Load() != 0 {
+		t.Fatalf("Expected lazy dependencies not to be constructed before the dependent is asked")
+	}
+
+	if result := AskInterface[testInterface]().TestFunc(); result != "firstTestString secondTestString" {
+		t.Errorf("Expected value %v, got %v", "firstTestString secondTestString", result)
+	}
+	if constructed.Load() != 3 {
+		t.Errorf("Expected 3 constructed beans, got %v", constructed.Load())
+	}
+}
+
+func TestMemoryContext_RegLazy_PendingInterface(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	interfaceDep := DepInterface[testInterface]()
+	Reg(func() *missingStruct {
+		ResolveDep[testInterface](interfaceDep)
+		return &missingStruct{}
+	}, interfaceDep)
+	asked := make(chan string, 1)
+	go func() {
+		asked <- AskInterface[testInterface]().TestFunc()
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	Reg(func() *firstIndependentStruct {
+		return &firstIndependentStruct{"firstTestString"}
+	})
+	Reg(func() *secondIndependentStruct {
+		return &secondIndependentStruct{"secondTestString"}
+	})
+	firstDep := Dep[*firstIndependentStruct]()
+	secondDep := Dep[*secondIndependentStruct]()
+	RegLazy(func() *dependentStruct {
+		return &dependentStruct{
+			firstDep:  ResolveDep[*firstIndependentStruct](firstDep),
+			secondDep: ResolveDep[*secondIndependentStruct](secondDep),
+		}
+	}, firstDep, secondDep)
+
+	select {
+	case result := <-asked:
+		if result != "firstTestString secondTestString" {
+			t.Errorf("Expected value %v, got %v", "firstTestString secondTestString", result)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Expected a pending AskInterface to start the lazy implementation")
+	}
+	if err := WaitReady(stdcontext.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
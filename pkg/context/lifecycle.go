@@ -0,0 +1,116 @@
+package context
+
+import (
+	stdcontext "context"
+	"errors"
+	"io"
+	"reflect"
+)
+
+type (
+	destroyer interface {
+		Destroy()
+	}
+
+	contextDestroyer interface {
+		Destroy(ctx stdcontext.Context) error
+	}
+
+	destroyTarget struct {
+		reg       *registration
+		instances []any
+	}
+)
+
+func (m *memoryContext) trackPrototype(reg *registration, value any) {
+	m.lock.Lock()
+	defer m.unlock()
+	reg.prototypes = append(reg.prototypes, value)
+}
+
+func (m *memoryContext) Shutdown(ctx stdcontext.Context) error {
+	var errs []error
+	for _, target := range m.destroyOrder() {
+		for i := len(target.instances) - 1; i >= 0; i-- {
+			if err := ctx.Err(); err != nil {
+				return errors.Join(append(errs, err)...)
+			}
+			err := destroy(ctx, target.instances[i])
+			if err != nil {
+				err = &BeanError{Type: reflect.TypeOf(target.reg.typ).Elem(), Scope: target.reg.scope, Err: err}
+				errs = append(errs, err)
+			}
+			m.emit(func(l Listener) {
+				l.BeanDestroyed(newBeanRef(target.reg.scope, target.reg.typ), err)
+			})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (m *memoryContext) destroyOrder() []destroyTarget {
+	m.lock.Lock()
+	defer m.unlock()
+
+	var regs []*registration
+	for _, scope := range m.registrations {
+		for _, reg := range scope {
+			regs = append(regs, reg)
+		}
+	}
+	sortRegistrations(regs)
+
+	var order []destroyTarget
+	visited := map[*registration]bool{}
+	var visit func(reg *registration)
+	visit = func(reg *registration) {
+		if visited[reg] {
+			return
+		}
+		visited[reg] = true
+		for _, r := range reg.requests {
+			for _, target := range m.dependencyTargets(r) {
+				visit(target)
+			}
+		}
+		if reg.destroyed {
+			return
+		}
+		reg.destroyed = true
+		instances := reg.prototypes
+		reg.prototypes = nil
+		if instance, ok := m.storage[reg.scope][reflect.TypeOf(reg.typ)]; ok && !isFactoryOrFailure(instance) {
+			instances = append([]any{instance}, instances...)
+		}
+		order = append(order, destroyTarget{reg, instances})
+	}
+	for _, reg := range regs {
+		visit(reg)
+	}
+
+	for i, j := 0, len(order)-1; i < j; i, j = i+1, j-1 {
+		order[i], order[j] = order[j], order[i]
+	}
+	return order
+}
+
+func isFactoryOrFailure(instance any) bool {
+	switch instance.(type) {
+	case factory, *beanFailure:
+		return true
+	}
+	return false
+}
+
+func destroy(ctx stdcontext.Context, instance any) error {
+	switch v := instance.(type) {
+	case contextDestroyer:
+		return v.Destroy(ctx)
+	case destroyer:
+		v.Destroy()
+		return nil
+	case io.Closer:
+		return v.Close()
+	}
+	return nil
+}
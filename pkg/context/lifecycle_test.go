@@ -0,0 +1,113 @@
+package context
+
+import (
+	stdcontext "context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type destroyLog struct {
+	names []string
+}
+
+type lifecyclePool struct {
+	log *destroyLog
+}
+
+type lifecycleRepo struct {
+	log  *destroyLog
+	pool *lifecyclePool
+}
+
+type lifecycleServer struct {
+	log  *destroyLog
+	repo *lifecycleRepo
+}
+
+type lifecycleSession struct {
+	log  *destroyLog
+	name string
+}
+
+type lifecycleUntracked struct {
+	log *destroyLog
+}
+
+var errServerStop = errors.New("server stop failed")
+
+func (p *lifecyclePool) Close() error {
+	p.log.names = append(p.log.names, "pool")
+	return nil
+}
+
+func (r *lifecycleRepo) Destroy() {
+	r.log.names = append(r.log.names, "repo")
+}
+
+func (s *lifecycleServer) Destroy(ctx stdcontext.Context) error {
+	s.log.names = append(s.log.names, "server")
+	return errServerStop
+}
+
+func (s *lifecycleSession) Close() error {
+	s.log.names = append(s.log.names, s.name)
+	return nil
+}
+
+func (u *lifecycleUntracked) Close() error {
+	u.log.names = append(u.log.names, "untracked")
+	return nil
+}
+
+func TestMemoryContext_Shutdown(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	log := &destroyLog{}
+
+	repoDep := Dep[*lifecycleRepo]()
+	Reg(func() *lifecycleServer {
+		return &lifecycleServer{log: log, repo: ResolveDep[*lifecycleRepo](repoDep)}
+	}, repoDep)
+
+	poolDep := Dep[*lifecyclePool]()
+	Reg(func() *lifecycleRepo {
+		return &lifecycleRepo{log: log, pool: ResolveDep[*lifecyclePool](poolDep)}
+	}, poolDep)
+
+	Reg(func() *lifecyclePool {
+		return &lifecyclePool{log: log}
+	})
+
+	sessions := 0
+	sessionPoolDep := Dep[*lifecyclePool]()
+	RegPrototype(func() *lifecycleSession {
+		ResolveDep[*lifecyclePool](sessionPoolDep)
+		sessions++
+		return &lifecycleSession{log: log, name: "session" + string(rune('0'+sessions))}
+	}, sessionPoolDep, TrackPrototypes())
+
+	RegPrototype(func() *lifecycleUntracked {
+		return &lifecycleUntracked{log: log}
+	})
+
+	Ask[*lifecycleServer]()
+	Ask[*lifecycleSession]()
+	Ask[*lifecycleSession]()
+	Ask[*lifecycleUntracked]()
+
+	ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), time.Second)
+	defer cancel()
+	err := Shutdown(ctx)
+	if !errors.Is(err, errServerStop) {
+		t.Errorf("Expected %v, got %v", errServerStop, err)
+	}
+	expected := []string{"session2", "session1", "server", "repo", "pool"}
+	if !reflect.DeepEqual(log.names, expected) {
+		t.Errorf("Expected destroy order %v, got %v", expected, log.names)
+	}
+
+	if err := Shutdown(ctx); err != nil {
+		t.Errorf("Expected second shutdown to be a no-op, got %v", err)
+	}
+}
@@ -0,0 +1,160 @@
+package context
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+)
+
+type (
+	Listener interface {
+		BeanRegistered(bean BeanRef, callSite string)
+		WaitStarted(bean BeanRef, dependency BeanRef)
+		WaiterSatisfied(bean BeanRef, dependency BeanRef)
+		ConstructionStarted(bean BeanRef)
+		ConstructionFinished(bean BeanRef, duration time.Duration)
+		BeanFailed(bean BeanRef, err error)
+		BeanDestroyed(bean BeanRef, err error)
+	}
+
+	NopListener struct{}
+
+	slogListener struct {
+		logger *slog.Logger
+	}
+
+	subscription struct {
+		Listener
+	}
+
+	listeners struct {
+		subscriptions []*subscription
+		events        []func()
+		lock          sync.RWMutex
+	}
+)
+
+func (NopListener) BeanRegistered(BeanRef, string)              {}
+func (NopListener) WaitStarted(BeanRef, BeanRef)                {}
+func (NopListener) WaiterSatisfied(BeanRef, BeanRef)            {}
+func (NopListener) ConstructionStarted(BeanRef)                 {}
+func (NopListener) ConstructionFinished(BeanRef, time.Duration) {}
+func (NopListener) BeanFailed(BeanRef, error)                   {}
+func (NopListener) BeanDestroyed(BeanRef, error)                {}
+
+func NewSlogListener(logger *slog.Logger) Listener {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &slogListener{logger: logger}
+}
+
+func (l *slogListener) BeanRegistered(bean BeanRef, callSite string) {
+	l.logger.Debug("bean registered", beanAttrs(bean, slog.String("call_site", callSite))...)
+}
+
+func (l *slogListener) WaitStarted(bean BeanRef, dependency BeanRef) {
+	l.logger.Debug("dependency wait started", beanAttrs(bean, slog.String("dependency", dependency.String()))...)
+}
+
+func (l *slogListener) WaiterSatisfied(bean BeanRef, dependency BeanRef) {
+	l.logger.Debug("waiter satisfied", beanAttrs(bean, slog.String("dependency", dependency.String()))...)
+}
+
+func (l *slogListener) ConstructionStarted(bean BeanRef) {
+	l.logger.Debug("constructor started", beanAttrs(bean)...)
+}
+
+func (l *slogListener) ConstructionFinished(bean BeanRef, duration time.Duration) {
+	l.logger.Info("constructor finished", beanAttrs(bean, slog.Duration("duration", duration))...)
+}
+
+func (l *slogListener) BeanFailed(bean BeanRef, err error) {
+	l.logger.Error("bean failed", beanAttrs(bean, slog.Any("error", err))...)
+}
+
+func (l *slogListener) BeanDestroyed(bean BeanRef, err error) {
+	if err != nil {
+		l.logger.Error("bean destroy failed", beanAttrs(bean, slog.Any("error", err))...)
+		return
+	}
+	l.logger.Info("bean destroyed", beanAttrs(bean)...)
+}
+
+func beanAttrs(bean BeanRef, attrs ...any) []any {
+	if bean.Type == nil {
+		return append([]any{slog.String("bean", "Ask")}, attrs...)
+	}
+	return append([]any{slog.String("bean", bean.Type.String()), slog.String("scope", bean.Scope)}, attrs...)
+}
+
+func Subscribe(l Listener) func() {
+	return GetContext().Subscribe(l)
+}
+
+func (m *memoryContext) Subscribe(l Listener) func() {
+	s := &subscription{l}
+	m.listeners.lock.Lock()
+	defer m.listeners.lock.Unlock()
+	m.listeners.subscriptions = append(m.listeners.subscriptions, s)
+	return func() {
+		m.listeners.lock.Lock()
+		defer m.listeners.lock.Unlock()
+		for i, subscribed := range m.listeners.subscriptions {
+			if subscribed == s {
+				m.listeners.subscriptions = append(m.listeners.subscriptions[:i:i], m.listeners.subscriptions[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+func (m *memoryContext) emit(event func(l Listener)) {
+	m.queue(event)
+	m.dispatch()
+}
+
+func (m *memoryContext) queue(event func(l Listener)) {
+	m.listeners.lock.RLock()
+	subscriptions := m.listeners.subscriptions
+	m.listeners.lock.RUnlock()
+	m.post(func() {
+		for _, s := range subscriptions {
+			event(s.Listener)
+		}
+	})
+}
+
+func (m *memoryContext) post(fn func()) {
+	m.listeners.lock.Lock()
+	defer m.listeners.lock.Unlock()
+	m.listeners.events = append(m.listeners.events, fn)
+}
+
+func (m *memoryContext) dispatch() {
+	m.listeners.lock.Lock()
+	events := m.listeners.events
+	m.listeners.events = nil
+	m.listeners.lock.Unlock()
+	for _, event := range events {
+		event()
+	}
+}
+
+func (m *memoryContext) unlock() {
+	m.lock.Unlock()
+	m.dispatch()
+}
+
+func (m *memoryContext) deliver(r *dependencyRequest, value any) {
+	if !r.delivered.CompareAndSwap(false, true) {
+		return
+	}
+	m.queue(func(l Listener) {
+		l.WaiterSatisfied(blockedBean(r).BeanRef, newBeanRef(r.Scope, r.Type))
+	})
+	value = m.proxy(r, value)
+	m.post(func() {
+		r.fulfil(value)
+	})
+}
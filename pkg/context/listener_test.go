@@ -0,0 +1,182 @@
+package context
+
+import (
+	"bytes"
+	stdcontext "context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordingListener struct {
+	NopListener
+	lock   sync.Mutex
+	events []string
+}
+
+func (l *recordingListener) record(format string, args ...any) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.events = append(l.events, fmt.Sprintf(format, args...))
+}
+
+func (l *recordingListener) has(event string) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	for _, e := range l.events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *recordingListener) BeanRegistered(bean BeanRef, callSite string) {
+	l.record("registered %v", bean.Type)
+}
+
+func (l *recordingListener) WaitStarted(bean BeanRef, dependency BeanRef) {
+	l.record("wait %v -> %v", bean.Type, dependency.Type)
+}
+
+func (l *recordingListener) WaiterSatisfied(bean BeanRef, dependency BeanRef) {
+	l.record("satisfied %v -> %v", bean.Type, dependency.Type)
+}
+
+func (l *recordingListener) ConstructionStarted(bean BeanRef) {
+	l.record("started %v", bean.Type)
+}
+
+func (l *recordingListener) ConstructionFinished(bean BeanRef, duration time.Duration) {
+	l.record("finished %v", bean.Type)
+}
+
+func (l *recordingListener) BeanFailed(bean BeanRef, err error) {
+	l.record("failed %v: %v", bean.Type, errors.Unwrap(err))
+}
+
+func (l *recordingListener) BeanDestroyed(bean BeanRef, err error) {
+	l.record("destroyed %v", bean.Type)
+}
+
+func TestMemoryContext_Listener(t *testing.T) {
+	c := NewMemoryContext()
+	useContext(t, c)
+	listener := &recordingListener{}
+	unsubscribe := Subscribe(listener)
+
+	Reg(func() *firstIndependentStruct {
+		return &firstIndependentStruct{"firstTestString"}
+	})
+	firstDep := Dep[*firstIndependentStruct]()
+	Reg(func() *dependentStruct {
+		return &dependentStruct{firstDep: ResolveDep[*firstIndependentStruct](firstDep)}
+	}, firstDep)
+	RegE(func() (*secondIndependentStruct, error) {
+		return nil, errors.New("boom")
+	})
+	Ask[*dependentStruct]()
+	if err := WaitReady(stdcontext.Background()); err == nil {
+		t.Fatalf("Expected failure")
+	}
+	if err := Shutdown(stdcontext.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, event := range []string{
+		"registered *context.firstIndependentStruct",
+		"wait *context.dependentStruct -> *context.firstIndependentStruct",
+		"satisfied *context.dependentStruct -> *context.firstIndependentStruct",
+		"satisfied <nil> -> *context.dependentStruct",
+		"started *context.firstIndependentStruct",
+		"finished *context.dependentStruct",
+		"failed *context.secondIndependentStruct: boom",
+		"destroyed *context.dependentStruct",
+	} {
+		if !listener.has(event) {
+			t.Errorf("Expected event %q in %v", event, listener.events)
+		}
+	}
+
+	unsubscribe()
+	Reg(func() *missingStruct {
+		return &missingStruct{}
+	})
+	if listener.has("registered *context.missingStruct") {
+		t.Errorf("Expected no events after unsubscribe")
+	}
+}
+
+func TestSlogListener(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	buf := &bytes.Buffer{}
+	defer Subscribe(NewSlogListener(slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))))()
+
+	Reg(func() *firstIndependentStruct {
+		return &firstIndependentStruct{"firstTestString"}
+	})
+	Ask[*firstIndependentStruct]()
+
+	for _, expected := range []string{
+		`msg="bean registered" bean=*context.firstIndependentStruct scope=""`,
+		`msg="constructor finished" bean=*context.firstIndependentStruct scope="" duration=`,
+		`msg="waiter satisfied" bean=Ask dependency="*context.firstIndependentStruct in scope \"\""`,
+	} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("Expected %q in log:\n%s", expected, buf.String())
+		}
+	}
+}
+
+type reentrantListener struct {
+	NopListener
+	context Context
+	graphs  chan string
+}
+
+func (l *reentrantListener) BeanRegistered(bean BeanRef, callSite string) {
+	l.context.HasBean(bean.Scope, reflect.Zero(reflect.PointerTo(bean.Type)).Interface())
+}
+
+func (l *reentrantListener) WaiterSatisfied(bean BeanRef, dependency BeanRef) {
+	l.context.GetUnresolvedRequests()
+}
+
+func (l *reentrantListener) BeanFailed(bean BeanRef, err error) {
+	buf := &bytes.Buffer{}
+	if err := l.context.ExportGraph(GraphDOT, buf); err != nil {
+		panic(err)
+	}
+	l.graphs <- buf.String()
+}
+
+func TestMemoryContext_Listener_Reentrant(t *testing.T) {
+	c := NewMemoryContext()
+	useContext(t, c)
+	listener := &reentrantListener{context: c, graphs: make(chan string, 1)}
+	defer Subscribe(listener)()
+
+	go func() {
+		Reg(func() *firstIndependentStruct {
+			return &firstIndependentStruct{"firstTestString"}
+		})
+		Ask[*firstIndependentStruct]()
+		RegE(func() (*secondIndependentStruct, error) {
+			return nil, errors.New("boom")
+		})
+	}()
+
+	select {
+	case graph := <-listener.graphs:
+		if !strings.Contains(graph, "secondIndependentStruct") {
+			t.Errorf("Expected failed bean in graph:\n%s", graph)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Expected listener to call back into the context without deadlock")
+	}
+}
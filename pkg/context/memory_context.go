@@ -1,25 +1,84 @@
 package context
 
 import (
+	"fmt"
 	"reflect"
+	"runtime/debug"
+	"sort"
 	"sync"
+	"time"
 )
 
+type registration struct {
+	scope           string
+	typ             any
+	order           int
+	constructor     func() interface{}
+	requests        []*dependencyRequest
+	callSite        string
+	trackPrototypes bool
+	prototype       bool
+	primary         bool
+	lazy            bool
+	started         bool
+	state           BeanState
+	prototypes      []any
+	views           map[reflect.Type]*interfaceView
+	destroyed       bool
+	startedAt       time.Time
+	constructingAt  time.Time
+	finishedAt      time.Time
+}
+
+type MemoryContextOption func(*memoryContext)
+
+type requestAttacher interface {
+	Context
+	lockedAttachRequest(r *dependencyRequest)
+	lockedCanResolve(r *dependencyRequest) bool
+}
+
 type memoryContext struct {
-	storage           map[string]map[any]interface{}
-	requests          map[string]map[any][]chan interface{}
-	interfaceRequests map[string]map[any][]chan interface{}
+	storage           map[string]map[reflect.Type]interface{}
+	registrations     map[string]map[reflect.Type]*registration
+	requests          map[string]map[reflect.Type][]*dependencyRequest
+	interfaceRequests map[string]map[reflect.Type][]*dependencyRequest
+	allRequests       map[string]map[reflect.Type][]*dependencyRequest
+	parent            requestAttacher
+	strictCycles      bool
+	frozen            bool
+	changed           chan struct{}
+	registered        int
+	index             typeIndex
+	profiles          []string
+	duplicatePolicy   DuplicatePolicy
+	lazyRegistrations bool
+	decorators        []*decorator
+	interceptors      map[interceptorKey]Interceptors
+	listeners         listeners
+	regLock           sync.Mutex
 	lock              *sync.RWMutex
 }
 
+func WithStrictCycles() MemoryContextOption {
+	return func(m *memoryContext) {
+		m.strictCycles = true
+	}
+}
+
 func (m *memoryContext) GetUnresolvedRequests() []*dependencyRequest {
 	m.lock.RLock()
 	defer m.lock.RUnlock()
 	var unresolvedRequests []*dependencyRequest
-	for scope, scopes := range m.requests {
-		for t, waiters := range scopes {
-			for _, waiter := range waiters {
-				unresolvedRequests = append(unresolvedRequests, &dependencyRequest{t, waiter, scope, false})
+	for _, requests := range []map[string]map[reflect.Type][]*dependencyRequest{m.requests, m.interfaceRequests, m.allRequests} {
+		for _, scopes := range requests {
+			for _, waiters := range scopes {
+				for _, waiter := range waiters {
+					if waiter.isDelivered() {
+						continue
+					}
+					unresolvedRequests = append(unresolvedRequests, waiter)
+				}
 			}
 		}
 	}
@@ -34,128 +93,347 @@ func (m *memoryContext) AskInterface(t any) chan interface{} {
 	return m.AskInterfaceScoped(DefaultScope, t)
 }
 
-func (m *memoryContext) appendWaiter(s string, t any, waiter chan interface{}) {
-	scope, ok := m.requests[s]
+func (m *memoryContext) appendWaiter(s string, t any, waiter *dependencyRequest) {
+	addWaiter(m.requests, s, t, waiter)
+}
+
+func (m *memoryContext) appendInterfaceWaiter(s string, t any, waiter *dependencyRequest) {
+	addWaiter(m.interfaceRequests, s, t, waiter)
+}
+
+func addWaiter(requests map[string]map[reflect.Type][]*dependencyRequest, s string, t any, waiter *dependencyRequest) {
+	scope, ok := requests[s]
 	if !ok {
-		scope = map[any][]chan interface{}{}
-		m.requests[s] = scope
+		scope = map[reflect.Type][]*dependencyRequest{}
+		requests[s] = scope
 	}
 
-	typ, ok := scope[t]
+	key := reflect.TypeOf(t)
+	typ, ok := scope[key]
 	if !ok {
-		typ = []chan any{}
-		scope[t] = typ
+		typ = []*dependencyRequest{}
+		scope[key] = typ
 	}
-	scope[t] = append(typ, waiter)
+	scope[key] = append(typ, waiter)
 }
 
-func (m *memoryContext) appendInterfaceWaiter(s string, t any, waiter chan interface{}) {
-	scope, ok := m.interfaceRequests[s]
-	if !ok {
-		scope = map[any][]chan interface{}{}
-		m.interfaceRequests[s] = scope
+func (m *memoryContext) CancelAsk(s string, t any, waiter chan interface{}) {
+	m.lock.Lock()
+	defer m.unlock()
+	removeWaiter(m.requests, s, waiter)
+	removeWaiter(m.interfaceRequests, s, waiter)
+	removeWaiter(m.allRequests, s, waiter)
+	if m.parent != nil {
+		m.parent.CancelAsk(s, t, waiter)
 	}
+}
 
-	typ, ok := scope[t]
+func removeWaiter(requests map[string]map[reflect.Type][]*dependencyRequest, s string, waiter chan interface{}) {
+	scope, ok := requests[s]
 	if !ok {
-		typ = []chan any{}
-		scope[t] = typ
+		return
+	}
+	for t, waiters := range scope {
+		for i, w := range waiters {
+			if w.Waiter != waiter {
+				continue
+			}
+			waiters = append(waiters[:i:i], waiters[i+1:]...)
+			break
+		}
+		if len(waiters) == 0 {
+			delete(scope, t)
+			continue
+		}
+		scope[t] = waiters
 	}
-	scope[t] = append(typ, waiter)
 }
 
-func (m *memoryContext) Reg(t any, constructor func() interface{}, requests ...*dependencyRequest) {
-	m.RegScoped(DefaultScope, t, constructor, requests...)
+func (m *memoryContext) Reg(t any, constructor func() interface{}, options ...RegOption) {
+	m.RegScoped(DefaultScope, t, constructor, options...)
 }
 
-func (m *memoryContext) RegScoped(s string, t any, constructor func() interface{}, requests ...*dependencyRequest) {
+func (m *memoryContext) RegScoped(s string, t any, constructor func() interface{}, options ...RegOption) {
+	opts := newRegOptions(options)
+	defer m.dispatch()
+	m.regLock.Lock()
+	defer m.regLock.Unlock()
+	if !opts.matches(m) {
+		return
+	}
+	reg := &registration{
+		scope:           s,
+		typ:             t,
+		constructor:     constructor,
+		requests:        opts.requests,
+		callSite:        callSite(),
+		trackPrototypes: opts.trackPrototypes,
+		prototype:       opts.prototype,
+		primary:         opts.primary,
+		lazy:            opts.lazy || m.lazyRegistrations,
+		state:           StateWaiting,
+	}
+
+	for _, r := range reg.requests {
+		r.owner = reg
+		r.context = m
+	}
+
 	m.lock.Lock()
-	defer m.lock.Unlock()
+	defer m.unlock()
 
+	if m.frozen {
+		panic(fmt.Errorf("%w: can't register %v", ErrFrozen, newBeanRef(s, t)))
+	}
+	if old, ok := m.registrations[s][reflect.TypeOf(t)]; ok && !m.lockedDuplicate(old, reg, opts.replace) {
+		return
+	}
+
+	m.addRegistration(reg)
+	m.queue(func(l Listener) {
+		l.BeanRegistered(newBeanRef(reg.scope, reg.typ), reg.callSite)
+	})
+	if cycle := m.findCycle(reg); cycle != nil {
+		if m.strictCycles {
+			delete(m.registrations[s], reflect.TypeOf(t))
+			m.index.remove(reg)
+			panic(cycle)
+		}
+		m.store(reg, &beanFailure{cycle})
+		return
+	}
+
+	m.notifyInterfaces(s, t, nil)
+	if reg.lazy {
+		reg.state = StateLazy
+		return
+	}
+	m.start(reg)
+}
+
+func (m *memoryContext) start(reg *registration) {
+	reg.started = true
+	reg.state = StateWaiting
+	reg.startedAt = time.Now()
 	go func() {
-		instance := constructor()
+		instance := m.construct(reg)
 		m.lock.Lock()
-		defer m.lock.Unlock()
-		scope, ok := m.storage[s]
-		if !ok {
-			scope = map[any]interface{}{}
-			m.storage[s] = scope
+		defer m.unlock()
+		if m.isRegistered(reg) {
+			m.store(reg, instance)
 		}
-
-		scope[t] = instance
-		m.notify(s, t, instance)
-		m.notifyInterfaces(s, t, instance)
 	}()
-	for _, r := range requests {
-		if foundScope, ok := m.storage[r.Scope]; ok {
-			if found, ok := foundScope[r.Type]; ok {
-				r.Waiter <- found
-				continue
-			}
-		}
-		if r.toInterface {
-			m.appendInterfaceWaiter(r.Scope, r.Type, r.Waiter)
-		} else {
-			m.appendWaiter(r.Scope, r.Type, r.Waiter)
-		}
+	for _, r := range reg.requests {
+		m.attachRequest(r)
 	}
 }
 
-func (m *memoryContext) AskScoped(s string, t any) chan interface{} {
-	m.lock.RLock()
-	defer m.lock.RUnlock()
+func (m *memoryContext) startLazy(reg *registration) {
+	if reg.lazy && !reg.started {
+		m.start(reg)
+	}
+}
 
-	waiter := make(chan any, 1)
+func (m *memoryContext) attachRequest(r *dependencyRequest) {
+	switch {
+	case r.all:
+		m.appendAllWaiter(r.Scope, r.Type, r)
+	case r.toInterface:
+		m.attachInterfaceWaiter(r.Scope, r.Type, r)
+	default:
+		m.attachWaiter(r.Scope, r.Type, r)
+	}
+}
 
+func (m *memoryContext) attachWaiter(s string, t any, waiter *dependencyRequest) {
 	if foundScope, ok := m.storage[s]; ok {
-		if found, ok := foundScope[t]; ok {
-			waiter <- found
-			return waiter
+		if found, ok := foundScope[reflect.TypeOf(t)]; ok {
+			m.deliver(waiter, found)
+			return
 		}
 	}
-
 	m.appendWaiter(s, t, waiter)
-	return waiter
+	reg, ok := m.registrations[s][reflect.TypeOf(t)]
+	if ok {
+		m.startLazy(reg)
+	} else if m.parent != nil {
+		m.parent.lockedAttachRequest(waiter)
+	}
 }
 
-func (m *memoryContext) AskInterfaceScoped(s string, t any) chan interface{} {
-	m.lock.RLock()
-	defer m.lock.RUnlock()
+func (m *memoryContext) lockedAttachRequest(r *dependencyRequest) {
+	m.lock.Lock()
+	defer m.unlock()
+	m.attachRequest(r)
+}
 
-	waiter := make(chan any, 1)
-
-	tType := reflect.TypeOf(t)
-	if tType.Elem().Kind() == reflect.Interface {
-		if foundScope, ok := m.storage[s]; ok {
-			for v, val := range foundScope {
-				valueType := reflect.TypeOf(v)
-				ifaceType := tType.Elem()
-				if valueType.Elem().Implements(ifaceType) {
-					waiter <- val
-					return waiter
-				}
-			}
+func (m *memoryContext) addRegistration(reg *registration) {
+	if reg.order == 0 {
+		m.registered++
+		reg.order = m.registered
+	}
+	scope, ok := m.registrations[reg.scope]
+	if !ok {
+		scope = map[reflect.Type]*registration{}
+		m.registrations[reg.scope] = scope
+	}
+	scope[reflect.TypeOf(reg.typ)] = reg
+	m.index.add(reg)
+}
+
+func sortRegistrations(regs []*registration) {
+	sort.Slice(regs, func(i, j int) bool {
+		return regs[i].order < regs[j].order
+	})
+}
+
+func (m *memoryContext) store(reg *registration, instance interface{}) {
+	scope, ok := m.storage[reg.scope]
+	if !ok {
+		scope = map[reflect.Type]interface{}{}
+		m.storage[reg.scope] = scope
+	}
+
+	scope[reflect.TypeOf(reg.typ)] = instance
+	reg.finishedAt = time.Now()
+	reg.state = StateReady
+	if failure, ok := instance.(*beanFailure); ok {
+		reg.state = StateFailed
+		m.queue(func(l Listener) {
+			l.BeanFailed(newBeanRef(reg.scope, reg.typ), failure.err)
+		})
+	}
+	m.notify(reg.scope, reg.typ, instance)
+	m.notifyInterfaces(reg.scope, reg.typ, instance)
+	m.notifyAll()
+	close(m.changed)
+	m.changed = make(chan struct{})
+}
+
+func (m *memoryContext) markConstructing(reg *registration) bool {
+	m.lock.Lock()
+	defer m.unlock()
+	if !m.isRegistered(reg) {
+		return false
+	}
+	reg.state = StateConstructing
+	reg.constructingAt = time.Now()
+	return true
+}
+
+func (m *memoryContext) construct(reg *registration) (instance interface{}) {
+	bean := newBeanRef(reg.scope, reg.typ)
+	for _, r := range reg.requests {
+		m.emit(func(l Listener) {
+			l.WaitStarted(bean, newBeanRef(r.Scope, r.Type))
+		})
+		<-r.done
+		value := r.value
+		if failure, ok := value.(*beanFailure); ok {
+			return newBeanFailure(reg.scope, reg.typ, failure.err)
+		}
+	}
+	if !m.markConstructing(reg) {
+		return &beanFailure{errReplaced}
+	}
+	m.emit(func(l Listener) {
+		l.ConstructionStarted(bean)
+	})
+	started := time.Now()
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
 		}
+		if err, ok := r.(*BeanError); ok {
+			instance = newBeanFailure(reg.scope, reg.typ, err)
+			return
+		}
+		instance = &beanFailure{&BeanConstructionError{
+			Type:     reflect.TypeOf(reg.typ).Elem(),
+			Scope:    reg.scope,
+			Value:    r,
+			Stack:    debug.Stack(),
+			CallSite: reg.callSite,
+		}}
+	}()
+	instance = reg.constructor()
+	if failure, ok := instance.(*beanFailure); ok {
+		return newBeanFailure(reg.scope, reg.typ, failure.err)
+	}
+	if f, ok := instance.(factory); ok {
+		f.onProduce(func(value any) (any, error) {
+			value, err := m.decorate(reg, value)
+			if err == nil && reg.trackPrototypes {
+				m.trackPrototype(reg, value)
+			}
+			return value, err
+		})
 	} else {
+		var err error
+		if instance, err = m.decorate(reg, instance); err != nil {
+			return newBeanFailure(reg.scope, reg.typ, err)
+		}
+	}
+	duration := time.Since(started)
+	m.emit(func(l Listener) {
+		l.ConstructionFinished(bean, duration)
+	})
+	return instance
+}
+
+func (m *memoryContext) AskScoped(s string, t any) chan interface{} {
+	m.lock.Lock()
+	defer m.unlock()
+
+	waiter := newAskRequest(s, t, false)
+	m.attachWaiter(s, t, waiter)
+	return waiter.Waiter
+}
+
+func (m *memoryContext) AskInterfaceScoped(s string, t any) chan interface{} {
+	if reflect.TypeOf(t).Elem().Kind() != reflect.Interface {
 		panic("unexpected type, interface type expected")
 	}
 
-	m.appendInterfaceWaiter(s, t, waiter)
-	return waiter
+	m.lock.Lock()
+	defer m.unlock()
+
+	waiter := newAskRequest(s, t, true)
+	m.attachInterfaceWaiter(s, t, waiter)
+	return waiter.Waiter
+}
+
+func (m *memoryContext) attachInterfaceWaiter(s string, t any, waiter *dependencyRequest) {
+	reg, err := m.resolveInterface(s, t)
+	if err != nil {
+		m.deliver(waiter, &beanFailure{err})
+		return
+	}
+	if reg == nil {
+		m.appendInterfaceWaiter(s, t, waiter)
+		if m.parent != nil {
+			m.parent.lockedAttachRequest(waiter)
+		}
+		return
+	}
+	if found, ok := m.storage[s][reflect.TypeOf(reg.typ)]; ok {
+		m.deliver(waiter, m.view(reg, t, found))
+		return
+	}
+	m.appendWaiter(s, reg.typ, waiter)
+	m.startLazy(reg)
 }
 
 func (m *memoryContext) notifyInterfaces(s string, valueTypeValue any, value interface{}) {
 	if scope, ok := m.interfaceRequests[s]; ok {
-		valueType := reflect.TypeOf(valueTypeValue)
 		for t, waiters := range scope {
-			tType := reflect.TypeOf(t)
-			ifaceType := tType.Elem()
-			if valueType.Elem().Implements(ifaceType) {
+			if implements(reflect.TypeOf(valueTypeValue), t) {
+				delete(scope, t)
 				for _, w := range waiters {
-					w <- value
+					m.attachInterfaceWaiter(s, w.Type, w)
 				}
-				delete(scope, t)
-				return
 			}
 		}
 	}
@@ -163,20 +441,51 @@ func (m *memoryContext) notifyInterfaces(s string, valueTypeValue any, value int
 
 func (m *memoryContext) notify(s string, t any, value interface{}) {
 	if scope, ok := m.requests[s]; ok {
-		if waiters, ok := scope[t]; ok {
+		if waiters, ok := scope[reflect.TypeOf(t)]; ok {
+			reg := m.registrations[s][reflect.TypeOf(t)]
 			for _, w := range waiters {
-				w <- value
+				if w.toInterface {
+					m.deliver(w, m.view(reg, w.Type, value))
+					continue
+				}
+				m.deliver(w, value)
 			}
-			delete(scope, t)
+			delete(scope, reflect.TypeOf(t))
 		}
 	}
 }
 
-func NewMemoryContext() Context {
-	return &memoryContext{
-		storage:           map[string]map[any]interface{}{},
-		requests:          map[string]map[any][]chan interface{}{},
-		interfaceRequests: map[string]map[any][]chan interface{}{},
+func NewMemoryContext(options ...MemoryContextOption) Context {
+	return newMemoryContext(nil, options)
+}
+
+func NewChildContext(parent Context, options ...MemoryContextOption) Context {
+	p, ok := parent.(requestAttacher)
+	if !ok {
+		panic(fmt.Sprintf("unsupported parent context %T", parent))
+	}
+	return newMemoryContext(p, options)
+}
+
+func newMemoryContext(parent requestAttacher, options []MemoryContextOption) *memoryContext {
+	m := &memoryContext{
+		storage:           map[string]map[reflect.Type]interface{}{},
+		registrations:     map[string]map[reflect.Type]*registration{},
+		requests:          map[string]map[reflect.Type][]*dependencyRequest{},
+		interfaceRequests: map[string]map[reflect.Type][]*dependencyRequest{},
+		allRequests:       map[string]map[reflect.Type][]*dependencyRequest{},
+		parent:            parent,
+		changed:           make(chan struct{}),
+		interceptors:      map[interceptorKey]Interceptors{},
 		lock:              &sync.RWMutex{},
 	}
+	if parent != nil {
+		m.profiles = parent.ActiveProfiles()
+	} else {
+		m.profiles = profilesFromEnv()
+	}
+	for _, option := range options {
+		option(m)
+	}
+	return m
 }
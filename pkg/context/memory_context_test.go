@@ -1,8 +1,12 @@
 package context
 
 import (
+	stdcontext "context"
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 )
 
 func init() {
@@ -18,6 +22,7 @@ type firstIndependentStruct struct {
 type secondIndependentStruct struct {
 	val string
 }
+type missingStruct struct{}
 type dependentStruct struct {
 	firstDep  *firstIndependentStruct
 	secondDep *secondIndependentStruct
@@ -27,7 +32,16 @@ func (t *dependentStruct) TestFunc() string {
 	return fmt.Sprintf("%v %v", t.firstDep.val, t.secondDep.val)
 }
 
+func useContext(t *testing.T, c Context) {
+	previous := GetContext()
+	SetContext(c)
+	t.Cleanup(func() {
+		SetContext(previous)
+	})
+}
+
 func TestMemoryContext_DefaultContext(t *testing.T) {
+	useContext(t, NewMemoryContext())
 	Reg(func() *firstIndependentStruct {
 		t.Log("Start init firstIndependentStruct")
 		return &firstIndependentStruct{"firstTestString"}
@@ -38,8 +52,8 @@ func TestMemoryContext_DefaultContext(t *testing.T) {
 	Reg(func() *dependentStruct {
 		t.Log("Start init dependentStruct")
 		return &dependentStruct{
-			firstDep:  (<-firstDep.Waiter).(*firstIndependentStruct),
-			secondDep: (<-secondDep.Waiter).(*secondIndependentStruct),
+			firstDep:  firstDep.Get(),
+			secondDep: secondDep.Get(),
 		}
 	}, firstDep, secondDep)
 
@@ -58,6 +72,7 @@ func TestMemoryContext_DefaultContext(t *testing.T) {
 }
 
 func TestMemoryContext_CustomContext(t *testing.T) {
+	useContext(t, NewMemoryContext())
 	const customScopeName = "custom"
 	RegScoped(customScopeName, func() *firstIndependentStruct {
 		t.Log("Start init firstIndependentStruct")
@@ -69,8 +84,8 @@ func TestMemoryContext_CustomContext(t *testing.T) {
 	RegScoped(customScopeName, func() *dependentStruct {
 		t.Log("Start init dependentStruct")
 		return &dependentStruct{
-			firstDep:  (<-firstDep.Waiter).(*firstIndependentStruct),
-			secondDep: (<-secondDep.Waiter).(*secondIndependentStruct),
+			firstDep:  firstDep.Get(),
+			secondDep: secondDep.Get(),
 		}
 	}, firstDep, secondDep)
 
@@ -90,6 +105,7 @@ func TestMemoryContext_CustomContext(t *testing.T) {
 }
 
 func TestMemoryContext_DefaultContext_DuckTyping(t *testing.T) {
+	useContext(t, NewMemoryContext())
 	Reg(func() *firstIndependentStruct {
 		t.Log("Start init firstIndependentStruct")
 		return &firstIndependentStruct{"firstTestString"}
@@ -100,8 +116,8 @@ func TestMemoryContext_DefaultContext_DuckTyping(t *testing.T) {
 	Reg(func() *dependentStruct {
 		t.Log("Start init dependentStruct")
 		return &dependentStruct{
-			firstDep:  (<-firstDep.Waiter).(*firstIndependentStruct),
-			secondDep: (<-secondDep.Waiter).(*secondIndependentStruct),
+			firstDep:  firstDep.Get(),
+			secondDep: secondDep.Get(),
 		}
 	}, firstDep, secondDep)
 
@@ -122,6 +138,7 @@ func TestMemoryContext_DefaultContext_DuckTyping(t *testing.T) {
 }
 
 func TestMemoryContext_CustomContext_DuckTyping(t *testing.T) {
+	useContext(t, NewMemoryContext())
 	const customScopeName = "custom"
 	RegScoped(customScopeName, func() *firstIndependentStruct {
 		t.Log("Start init firstIndependentStruct")
@@ -133,8 +150,8 @@ func TestMemoryContext_CustomContext_DuckTyping(t *testing.T) {
 	RegScoped(customScopeName, func() *dependentStruct {
 		t.Log("Start init dependentStruct")
 		return &dependentStruct{
-			firstDep:  (<-firstDep.Waiter).(*firstIndependentStruct),
-			secondDep: (<-secondDep.Waiter).(*secondIndependentStruct),
+			firstDep:  firstDep.Get(),
+			secondDep: secondDep.Get(),
 		}
 	}, firstDep, secondDep)
 
@@ -154,3 +171,155 @@ func TestMemoryContext_CustomContext_DuckTyping(t *testing.T) {
 	}
 	t.Errorf("Expected value '%v'", "firstTestString secondTestString")
 }
+
+func TestMemoryContext_AskCtx(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	Reg(func() *firstIndependentStruct {
+		return &firstIndependentStruct{"firstTestString"}
+	})
+
+	ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), time.Second)
+	defer cancel()
+	actualInst, err := AskCtx[*firstIndependentStruct](ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if actualInst.val != "firstTestString" {
+		t.Errorf("Expected value %v", "firstTestString")
+	}
+}
+
+func TestMemoryContext_AskCtx_Timeout(t *testing.T) {
+	ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := AskScopedCtx[*missingStruct](ctx, "missing")
+	if !errors.Is(err, stdcontext.DeadlineExceeded) {
+		t.Fatalf("Expected deadline error, got %v", err)
+	}
+	var unresolvedErr *UnresolvedError
+	if !errors.As(err, &unresolvedErr) {
+		t.Fatalf("Expected UnresolvedError, got %T", err)
+	}
+	if unresolvedErr.Scope != "missing" || !strings.Contains(err.Error(), `*context.missingStruct in scope "missing"`) {
+		t.Errorf("Expected missing type and scope in %q", err.Error())
+	}
+	for _, r := range GetContext().GetUnresolvedRequests() {
+		if r.Scope == "missing" {
+			t.Errorf("Expected cancelled waiter to be removed")
+		}
+	}
+}
+
+func TestMemoryContext_RegE_FailurePropagation(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	rootErr := errors.New("connection refused")
+	RegE(func() (*firstIndependentStruct, error) {
+		return nil, rootErr
+	})
+
+	firstDep := Dep[*firstIndependentStruct]()
+	Reg(func() *dependentStruct {
+		t.Error("Constructor of a dependent bean must not be called")
+		return &dependentStruct{firstDep: ResolveDep[*firstIndependentStruct](firstDep)}
+	}, firstDep)
+
+	ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), time.Second)
+	defer cancel()
+	_, err := AskCtx[*dependentStruct](ctx)
+	if !errors.Is(err, rootErr) {
+		t.Fatalf("Expected wrapped %v, got %v", rootErr, err)
+	}
+	var beanErr *BeanError
+	if !errors.As(err, &beanErr) || beanErr.Type.String() != "*context.dependentStruct" {
+		t.Fatalf("Expected BeanError for dependentStruct, got %v", err)
+	}
+	if !errors.As(beanErr.Err, &beanErr) || beanErr.Type.String() != "*context.firstIndependentStruct" {
+		t.Fatalf("Expected BeanError for firstIndependentStruct, got %v", beanErr.Err)
+	}
+
+	defer func() {
+		if r := recover(); r == nil || !errors.Is(r.(error), rootErr) {
+			t.Errorf("Expected Ask to panic with %v, got %v", rootErr, r)
+		}
+	}()
+	Ask[*firstIndependentStruct]()
+}
+
+func TestMemoryContext_RegPrototypeE_Failure(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	rootErr := errors.New("no more instances")
+	calls := 0
+	RegPrototypeE(func() (*firstIndependentStruct, error) {
+		calls++
+		if calls > 1 {
+			return nil, rootErr
+		}
+		return &firstIndependentStruct{"firstTestString"}, nil
+	})
+
+	ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), time.Second)
+	defer cancel()
+	if _, err := AskCtx[*firstIndependentStruct](ctx); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if _, err := AskCtx[*firstIndependentStruct](ctx); !errors.Is(err, rootErr) {
+		t.Errorf("Expected wrapped %v, got %v", rootErr, err)
+	}
+}
+
+func TestMemoryContext_ConstructorPanic(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	Reg(func() *firstIndependentStruct {
+		panic("boom")
+	})
+
+	firstDep := Dep[*firstIndependentStruct]()
+	Reg(func() *dependentStruct {
+		return &dependentStruct{firstDep: ResolveDep[*firstIndependentStruct](firstDep)}
+	}, firstDep)
+
+	ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), time.Second)
+	defer cancel()
+	_, err := AskCtx[*dependentStruct](ctx)
+	var constructionErr *BeanConstructionError
+	if !errors.As(err, &constructionErr) {
+		t.Fatalf("Expected BeanConstructionError, got %v", err)
+	}
+	if constructionErr.Value != "boom" || constructionErr.Type.String() != "*context.firstIndependentStruct" {
+		t.Errorf("Unexpected construction error %v", constructionErr)
+	}
+	if !strings.Contains(constructionErr.CallSite, "memory_context_test.go") {
+		t.Errorf("Expected call site in test file, got %v", constructionErr.CallSite)
+	}
+	if len(constructionErr.Stack) == 0 {
+		t.Errorf("Expected stack trace")
+	}
+}
+
+func TestMemoryContext_PrototypeConstructorPanic(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	RegPrototype(func() *firstIndependentStruct {
+		panic("boom")
+	})
+	firstDep := Dep[*firstIndependentStruct]()
+	Reg(func() *dependentStruct {
+		return &dependentStruct{}
+	}, firstDep)
+
+	ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), time.Second)
+	defer cancel()
+	_, askErr := AskCtx[*firstIndependentStruct](ctx)
+	_, depErr := ResolveDepCtx(ctx, firstDep)
+	for _, err := range []error{askErr, depErr} {
+		var constructionErr *BeanConstructionError
+		if !errors.As(err, &constructionErr) {
+			t.Fatalf("Expected BeanConstructionError, got %v", err)
+		}
+		if constructionErr.Value != "boom" || constructionErr.Type.String() != "*context.firstIndependentStruct" {
+			t.Errorf("Unexpected construction error %v", constructionErr)
+		}
+		if !strings.Contains(constructionErr.CallSite, "memory_context_test.go") || len(constructionErr.Stack) == 0 {
+			t.Errorf("Expected call site and stack, got %v", constructionErr.CallSite)
+		}
+	}
+}
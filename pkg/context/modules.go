@@ -0,0 +1,43 @@
+package context
+
+import "sync"
+
+var modules struct {
+	registers []func()
+	running   int
+	lock      sync.Mutex
+}
+
+func WithLazyRegistrations() MemoryContextOption {
+	return func(m *memoryContext) {
+		m.lazyRegistrations = true
+	}
+}
+
+func RegModule(register func()) {
+	modules.lock.Lock()
+	if modules.running == 0 {
+		modules.registers = append(modules.registers, register)
+	}
+	modules.running++
+	modules.lock.Unlock()
+	defer finishModule()
+	register()
+}
+
+func ReplayModules() {
+	modules.lock.Lock()
+	registers := append([]func(){}, modules.registers...)
+	modules.running++
+	modules.lock.Unlock()
+	defer finishModule()
+	for _, register := range registers {
+		register()
+	}
+}
+
+func finishModule() {
+	modules.lock.Lock()
+	defer modules.lock.Unlock()
+	modules.running--
+}
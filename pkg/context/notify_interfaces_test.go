@@ -0,0 +1,107 @@
+package context
+
+import (
+	stdcontext "context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+type multiBean struct {
+	*strings.Reader
+	name string
+}
+
+func (b *multiBean) String() string {
+	return b.name
+}
+
+func (b *multiBean) Greet() string {
+	return "hello " + b.name
+}
+
+func askPending[T any](t *testing.T, c Context, scope string) func() (T, error) {
+	t.Helper()
+	waiter := c.AskInterfaceScoped(scope, (*T)(nil))
+	return func() (T, error) {
+		ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), time.Second)
+		defer cancel()
+		return awaitValue[T](ctx, c, scope, (*T)(nil), waiter)
+	}
+}
+
+func TestMemoryContext_NotifyInterfaces_Overlapping(t *testing.T) {
+	c := NewMemoryContext()
+	reader := askPending[io.Reader](t, c, DefaultScope)
+	stringer := askPending[fmt.Stringer](t, c, DefaultScope)
+	greeter := askPending[greeter](t, c, DefaultScope)
+	secondStringer := askPending[fmt.Stringer](t, c, DefaultScope)
+
+	c.Reg((**multiBean)(nil), func() interface{} {
+		return &multiBean{strings.NewReader("data"), "multi"}
+	})
+
+	if r, err := reader(); err != nil {
+		t.Errorf("Expected io.Reader waiter to be satisfied, got %v", err)
+	} else if data, _ := io.ReadAll(r); string(data) != "data" {
+		t.Errorf("Expected reader data, got %q", data)
+	}
+	for _, waiter := range []func() (fmt.Stringer, error){stringer, secondStringer} {
+		if s, err := waiter(); err != nil || s.String() != "multi" {
+			t.Errorf("Expected fmt.Stringer waiter to be satisfied, got %v %v", s, err)
+		}
+	}
+	if g, err := greeter(); err != nil || g.Greet() != "hello multi" {
+		t.Errorf("Expected greeter waiter to be satisfied, got %v %v", g, err)
+	}
+}
+
+func TestMemoryContext_NotifyInterfaces_Prototype(t *testing.T) {
+	c := NewMemoryContext()
+	useContext(t, c)
+	stringer := askPending[fmt.Stringer](t, c, DefaultScope)
+	greeter := askPending[greeter](t, c, DefaultScope)
+
+	count := 0
+	RegPrototype(func() *multiBean {
+		count++
+		return &multiBean{strings.NewReader(""), fmt.Sprint("proto", count)}
+	})
+
+	s, err := stringer()
+	if err != nil {
+		t.Fatalf("Expected fmt.Stringer waiter to be satisfied, got %v", err)
+	}
+	g, err := greeter()
+	if err != nil {
+		t.Fatalf("Expected greeter waiter to be satisfied, got %v", err)
+	}
+	if s == g.(fmt.Stringer) {
+		t.Errorf("Expected each waiter to get its own prototype instance")
+	}
+}
+
+func TestMemoryContext_NotifyInterfaces_Scoped(t *testing.T) {
+	c := NewMemoryContext()
+	reader := askPending[io.Reader](t, c, "a")
+	stringer := askPending[fmt.Stringer](t, c, "a")
+	otherScope := c.AskInterfaceScoped("b", (*fmt.Stringer)(nil))
+
+	c.RegScoped("a", (**multiBean)(nil), func() interface{} {
+		return &multiBean{strings.NewReader(""), "scoped"}
+	})
+
+	if _, err := reader(); err != nil {
+		t.Errorf("Expected io.Reader waiter in scope a to be satisfied, got %v", err)
+	}
+	if s, err := stringer(); err != nil || s.String() != "scoped" {
+		t.Errorf("Expected fmt.Stringer waiter in scope a to be satisfied, got %v %v", s, err)
+	}
+	select {
+	case value := <-otherScope:
+		t.Errorf("Expected waiter in scope b to stay pending, got %v", value)
+	case <-time.After(10 * time.Millisecond):
+	}
+}
@@ -0,0 +1,59 @@
+package context
+
+type (
+	RegOption interface {
+		applyReg(options *regOptions)
+	}
+
+	regOptions struct {
+		requests        []*dependencyRequest
+		trackPrototypes bool
+		prototype       bool
+		primary         bool
+		lazy            bool
+		conditions      []Condition
+		replace         bool
+	}
+
+	regOptionFunc func(options *regOptions)
+)
+
+func (f regOptionFunc) applyReg(options *regOptions) {
+	f(options)
+}
+
+func (r *dependencyRequest) applyReg(options *regOptions) {
+	options.requests = append(options.requests, r)
+}
+
+func TrackPrototypes() RegOption {
+	return regOptionFunc(func(options *regOptions) {
+		options.trackPrototypes = true
+	})
+}
+
+func Primary() RegOption {
+	return regOptionFunc(func(options *regOptions) {
+		options.primary = true
+	})
+}
+
+func Lazy() RegOption {
+	return regOptionFunc(func(options *regOptions) {
+		options.lazy = true
+	})
+}
+
+func asPrototype() RegOption {
+	return regOptionFunc(func(options *regOptions) {
+		options.prototype = true
+	})
+}
+
+func newRegOptions(options []RegOption) *regOptions {
+	res := &regOptions{}
+	for _, option := range options {
+		option.applyReg(res)
+	}
+	return res
+}
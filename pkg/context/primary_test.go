@@ -0,0 +1,119 @@
+package context
+
+import (
+	stdcontext "context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type primaryGreeter interface {
+	Greet() string
+}
+
+type englishGreeter struct{}
+
+type frenchGreeter struct{}
+
+func (*englishGreeter) Greet() string {
+	return "hello"
+}
+
+func (*frenchGreeter) Greet() string {
+	return "bonjour"
+}
+
+func TestMemoryContext_AskInterface_Ambiguous(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	Reg(func() *englishGreeter {
+		return &englishGreeter{}
+	})
+	Reg(func() *frenchGreeter {
+		return &frenchGreeter{}
+	})
+
+	ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), time.Second)
+	defer cancel()
+	_, err := AskInterfaceCtx[primaryGreeter](ctx)
+	var ambiguousErr *AmbiguousBeanError
+	if !errors.As(err, &ambiguousErr) {
+		t.Fatalf("Expected AmbiguousBeanError, got %v", err)
+	}
+	expected := `interface context.primaryGreeter in scope "" is ambiguous: *context.englishGreeter, *context.frenchGreeter implement it and none is primary`
+	if err.Error() != expected {
+		t.Errorf("Expected %q, got %q", expected, err.Error())
+	}
+}
+
+func TestMemoryContext_AskInterface_Primary(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	Reg(func() *englishGreeter {
+		return &englishGreeter{}
+	})
+	Reg(func() *frenchGreeter {
+		return &frenchGreeter{}
+	}, Primary())
+	greeterDep := DepInterface[primaryGreeter]()
+	Reg(func() *firstIndependentStruct {
+		return &firstIndependentStruct{ResolveDep[primaryGreeter](greeterDep).Greet()}
+	}, greeterDep)
+
+	for i := 0; i < 10; i++ {
+		if greeting := AskInterface[primaryGreeter]().Greet(); greeting != "bonjour" {
+			t.Fatalf("Expected primary bean, got %v", greeting)
+		}
+	}
+	if val := Ask[*firstIndependentStruct]().val; val != "bonjour" {
+		t.Errorf("Expected primary bean to be injected, got %v", val)
+	}
+}
+
+func TestMemoryContext_AskInterface_AlreadyConstructed(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	Reg(func() *englishGreeter {
+		return &englishGreeter{}
+	})
+	Ask[*englishGreeter]()
+
+	greeterDep := DepInterface[primaryGreeter]()
+	Reg(func() *firstIndependentStruct {
+		return &firstIndependentStruct{ResolveDep[primaryGreeter](greeterDep).Greet()}
+	}, greeterDep)
+
+	if val := Ask[*firstIndependentStruct]().val; val != "hello" {
+		t.Errorf("Expected constructed bean to be injected, got %v", val)
+	}
+}
+
+func TestMemoryContext_DepInterface_RegistrationOrder(t *testing.T) {
+	for _, pause := range []time.Duration{0, 5 * time.Millisecond} {
+		for _, primary := range []bool{false, true} {
+			useContext(t, NewMemoryContext())
+			greeterDep := DepInterface[primaryGreeter]()
+			Reg(func() *firstIndependentStruct {
+				return &firstIndependentStruct{ResolveDep[primaryGreeter](greeterDep).Greet()}
+			}, greeterDep)
+			Reg(func() *englishGreeter {
+				return &englishGreeter{}
+			})
+			time.Sleep(pause)
+			options := []RegOption{}
+			if primary {
+				options = append(options, Primary())
+			}
+			Reg(func() *frenchGreeter {
+				return &frenchGreeter{}
+			}, options...)
+
+			ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), time.Second)
+			bean, err := AskCtx[*firstIndependentStruct](ctx)
+			cancel()
+			if err != nil {
+				t.Fatalf("Expected the first registered implementation (pause %v, primary %v), got %v", pause, primary, err)
+			}
+			if bean.val != "hello" {
+				t.Errorf("Expected the first registered implementation (pause %v, primary %v), got %v", pause, primary, bean.val)
+			}
+		}
+	}
+}
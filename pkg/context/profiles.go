@@ -0,0 +1,132 @@
+package context
+
+import (
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+const ProfilesEnv = "IOC_PROFILES"
+
+type Condition func(c Context) bool
+
+func WithProfiles(profiles ...string) MemoryContextOption {
+	return func(m *memoryContext) {
+		m.profiles = profiles
+	}
+}
+
+func If(conditions ...Condition) RegOption {
+	return regOptionFunc(func(options *regOptions) {
+		options.conditions = append(options.conditions, conditions...)
+	})
+}
+
+func Profile(profiles ...string) RegOption {
+	return If(OnProfile(profiles...))
+}
+
+func OnProfile(profiles ...string) Condition {
+	return func(c Context) bool {
+		active := c.ActiveProfiles()
+		for _, profile := range profiles {
+			if name, negated := strings.CutPrefix(profile, "!"); negated {
+				if !slices.Contains(active, name) {
+					return true
+				}
+				continue
+			}
+			if slices.Contains(active, profile) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func OnBean[T any]() Condition {
+	return OnBeanScoped[T](DefaultScope)
+}
+
+func OnBeanScoped[T any](scope string) Condition {
+	return func(c Context) bool {
+		return c.HasBean(scope, (*T)(nil))
+	}
+}
+
+func OnMissingBean[T any]() Condition {
+	return OnMissingBeanScoped[T](DefaultScope)
+}
+
+func OnMissingBeanScoped[T any](scope string) Condition {
+	return func(c Context) bool {
+		return !c.HasBean(scope, (*T)(nil))
+	}
+}
+
+func OnProperty(name string, value string) Condition {
+	return func(c Context) bool {
+		actual, ok := os.LookupEnv(name)
+		return ok && (value == "" || actual == value)
+	}
+}
+
+func RegIf[T any](condition Condition, constructor func() T, options ...RegOption) {
+	Reg[T](constructor, append(options, If(condition))...)
+}
+
+func RegProfile[T any](profile string, constructor func() T, options ...RegOption) {
+	Reg[T](constructor, append(options, Profile(profile))...)
+}
+
+func SetProfiles(profiles ...string) {
+	GetContext().SetProfiles(profiles...)
+}
+
+func ActiveProfiles() []string {
+	return GetContext().ActiveProfiles()
+}
+
+func (m *memoryContext) SetProfiles(profiles ...string) {
+	m.lock.Lock()
+	defer m.unlock()
+	m.profiles = profiles
+}
+
+func (m *memoryContext) ActiveProfiles() []string {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return slices.Clone(m.profiles)
+}
+
+func (m *memoryContext) HasBean(s string, t any) bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	if _, ok := m.registrations[s][reflect.TypeOf(t)]; ok {
+		return true
+	}
+	if reflect.TypeOf(t).Elem().Kind() == reflect.Interface && len(m.implementations(s, t)) > 0 {
+		return true
+	}
+	return m.parent != nil && m.parent.HasBean(s, t)
+}
+
+func (o *regOptions) matches(c Context) bool {
+	for _, condition := range o.conditions {
+		if !condition(c) {
+			return false
+		}
+	}
+	return true
+}
+
+func profilesFromEnv() []string {
+	var profiles []string
+	for _, profile := range strings.Split(os.Getenv(ProfilesEnv), ",") {
+		if profile = strings.TrimSpace(profile); profile != "" {
+			profiles = append(profiles, profile)
+		}
+	}
+	return profiles
+}
@@ -0,0 +1,104 @@
+package context
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMemoryContext_RegProfile(t *testing.T) {
+	useContext(t, NewMemoryContext(WithProfiles("prod")))
+	RegProfile("dev", func() *plainGreeter {
+		return &plainGreeter{"dev"}
+	})
+	RegProfile("prod", func() *plainGreeter {
+		return &plainGreeter{"prod"}
+	})
+	Reg(func() *loudGreeter {
+		return &loudGreeter{&plainGreeter{"not dev"}}
+	}, Profile("!dev"))
+
+	if greeting := Ask[*plainGreeter]().Greet(); greeting != "prod" {
+		t.Errorf("Expected prod bean, got %v", greeting)
+	}
+	if greeting := Ask[*loudGreeter]().Greet(); greeting != "NOT DEV!" {
+		t.Errorf("Expected negated profile bean, got %v", greeting)
+	}
+}
+
+func TestMemoryContext_ProfilesFromEnv(t *testing.T) {
+	t.Setenv(ProfilesEnv, "dev, test,")
+	c := NewMemoryContext()
+	if profiles := c.ActiveProfiles(); !slices.Equal(profiles, []string{"dev", "test"}) {
+		t.Errorf("Expected profiles from env, got %v", profiles)
+	}
+	if profiles := NewChildContext(c).ActiveProfiles(); !slices.Equal(profiles, []string{"dev", "test"}) {
+		t.Errorf("Expected child to inherit profiles, got %v", profiles)
+	}
+	c.SetProfiles("prod")
+	if profiles := c.ActiveProfiles(); !slices.Equal(profiles, []string{"prod"}) {
+		t.Errorf("Expected profiles to be replaced, got %v", profiles)
+	}
+}
+
+func TestMemoryContext_OnMissingBean(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	Reg(func() *plainGreeter {
+		return &plainGreeter{"custom"}
+	})
+	RegIf(OnMissingBean[*plainGreeter](), func() *plainGreeter {
+		return &plainGreeter{"fallback"}
+	})
+	RegIf(OnMissingBean[greeter](), func() *loudGreeter {
+		return &loudGreeter{&plainGreeter{"fallback"}}
+	})
+	RegIf(OnBean[greeter](), func() string {
+		return "greeter present"
+	})
+
+	if greeting := Ask[*plainGreeter]().Greet(); greeting != "custom" {
+		t.Errorf("Expected first registration to win, got %v", greeting)
+	}
+	if !GetContext().HasBean(DefaultScope, (*string)(nil)) {
+		t.Errorf("Expected OnBean condition to match")
+	}
+	if GetContext().HasBean(DefaultScope, (**loudGreeter)(nil)) {
+		t.Errorf("Expected OnMissingBean condition to skip registration")
+	}
+}
+
+func TestMemoryContext_OnBean_Parent(t *testing.T) {
+	parent := NewMemoryContext()
+	parent.Reg((**plainGreeter)(nil), func() interface{} {
+		return &plainGreeter{"parent"}
+	})
+	child := NewChildContext(parent)
+	child.Reg((*string)(nil), func() interface{} {
+		return "child"
+	}, If(OnBean[*plainGreeter]()))
+
+	if !child.HasBean(DefaultScope, (*string)(nil)) {
+		t.Errorf("Expected bean registered in parent to satisfy OnBean")
+	}
+}
+
+func TestMemoryContext_OnProperty(t *testing.T) {
+	t.Setenv("IOC_TEST_FEATURE", "on")
+	useContext(t, NewMemoryContext())
+	RegIf(OnProperty("IOC_TEST_FEATURE", "on"), func() int {
+		return 1
+	})
+	RegIf(OnProperty("IOC_TEST_FEATURE", "off"), func() int64 {
+		return 2
+	})
+	RegIf(OnProperty("IOC_TEST_FEATURE", ""), func() uint {
+		return 3
+	})
+
+	c := GetContext()
+	if !c.HasBean(DefaultScope, (*int)(nil)) || !c.HasBean(DefaultScope, (*uint)(nil)) {
+		t.Errorf("Expected matching property conditions to register beans")
+	}
+	if c.HasBean(DefaultScope, (*int64)(nil)) {
+		t.Errorf("Expected mismatching property condition to skip registration")
+	}
+}
@@ -0,0 +1,101 @@
+package context
+
+import (
+	"reflect"
+	"sync"
+)
+
+type (
+	Invocation struct {
+		Method  string
+		Args    []any
+		Results []any
+	}
+
+	Interceptor func(inv *Invocation, proceed func())
+
+	Interceptors []Interceptor
+
+	interceptorKey struct {
+		scope string
+		typ   any
+	}
+
+	proxyFactory struct {
+		factory
+		wrap func(any) any
+	}
+)
+
+var proxies sync.Map
+
+func (c Interceptors) Invoke(inv *Invocation, call func()) {
+	var next func(i int)
+	next = func(i int) {
+		if i == len(c) {
+			call()
+			return
+		}
+		c[i](inv, func() {
+			next(i + 1)
+		})
+	}
+	next(0)
+}
+
+func RegProxy[I any](wrap func(target I, interceptors Interceptors) I) {
+	proxies.Store((*I)(nil), func(target any, interceptors Interceptors) any {
+		return wrap(target.(I), interceptors)
+	})
+}
+
+func RegInterceptor[I any](interceptor Interceptor) {
+	RegInterceptorScoped[I](DefaultScope, interceptor)
+}
+
+func RegInterceptorScoped[I any](scope string, interceptor Interceptor) {
+	GetContext().RegInterceptorScoped(scope, (*I)(nil), interceptor)
+}
+
+func (m *memoryContext) RegInterceptorScoped(s string, t any, interceptor Interceptor) {
+	if reflect.TypeOf(t).Elem().Kind() != reflect.Interface {
+		panic("unexpected type, interface type expected")
+	}
+
+	m.lock.Lock()
+	defer m.unlock()
+	key := interceptorKey{scope: s, typ: t}
+	m.interceptors[key] = append(m.interceptors[key], interceptor)
+}
+
+func (m *memoryContext) proxy(r *dependencyRequest, value any) any {
+	if !r.toInterface || r.all {
+		return value
+	}
+	interceptors := m.interceptors[interceptorKey{scope: r.Scope, typ: r.Type}]
+	if len(interceptors) == 0 {
+		return value
+	}
+	wrap, ok := proxies.Load(r.Type)
+	if !ok {
+		return value
+	}
+	proxied := func(target any) any {
+		return wrap.(func(any, Interceptors) any)(target, append(Interceptors{}, interceptors...))
+	}
+	switch v := value.(type) {
+	case *beanFailure:
+		return value
+	case factory:
+		return &proxyFactory{factory: v, wrap: proxied}
+	}
+	return proxied(value)
+}
+
+func (p *proxyFactory) produce() (any, error) {
+	value, err := p.factory.produce()
+	if err != nil {
+		return nil, err
+	}
+	return p.wrap(value), nil
+}
@@ -0,0 +1,94 @@
+package context
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type greeterProxy struct {
+	target       greeter
+	interceptors Interceptors
+}
+
+func (p *greeterProxy) Greet() (r0 string) {
+	inv := &Invocation{Method: "Greet", Args: []any{}}
+	p.interceptors.Invoke(inv, func() {
+		r0 = p.target.Greet()
+		inv.Results = []any{r0}
+	})
+	if len(inv.Results) == 1 {
+		r0, _ = inv.Results[0].(string)
+	}
+	return
+}
+
+func init() {
+	RegProxy(func(target greeter, interceptors Interceptors) greeter {
+		return &greeterProxy{target: target, interceptors: interceptors}
+	})
+}
+
+func TestMemoryContext_RegInterceptor(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	var calls []string
+	RegInterceptor[greeter](func(inv *Invocation, proceed func()) {
+		calls = append(calls, "outer before "+inv.Method)
+		proceed()
+		calls = append(calls, "outer after "+inv.Results[0].(string))
+	})
+	RegInterceptor[greeter](func(inv *Invocation, proceed func()) {
+		proceed()
+		inv.Results[0] = strings.ToUpper(inv.Results[0].(string))
+	})
+	Reg(func() *plainGreeter {
+		return &plainGreeter{"hello"}
+	})
+
+	g := AskInterface[greeter]()
+	if _, ok := g.(*greeterProxy); !ok {
+		t.Fatalf("Expected proxy, got %T", g)
+	}
+	if greeting := g.Greet(); greeting != "HELLO" {
+		t.Errorf("Expected intercepted result, got %v", greeting)
+	}
+	if strings.Join(calls, ", ") != "outer before Greet, outer after HELLO" {
+		t.Errorf("Unexpected interceptor order %v", calls)
+	}
+	if _, ok := interface{}(Ask[*plainGreeter]()).(*plainGreeter); !ok {
+		t.Errorf("Expected direct requests to get the raw bean")
+	}
+}
+
+func TestMemoryContext_RegInterceptor_NoInterceptors(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	RegPrototype(func() *plainGreeter {
+		return &plainGreeter{"hello"}
+	})
+	if g := AskInterface[greeter](); g.Greet() != "hello" {
+		t.Errorf("Unexpected greeting %v", g.Greet())
+	} else if _, ok := g.(*plainGreeter); !ok {
+		t.Errorf("Expected raw bean without interceptors, got %T", g)
+	}
+
+	RegInterceptorScoped[greeter](DefaultScope, func(inv *Invocation, proceed func()) {
+		inv.Results = []any{"short-circuited"}
+	})
+	if g := AskInterface[greeter](); g.Greet() != "short-circuited" {
+		t.Errorf("Expected interceptor to replace results of prototype, got %v", g.Greet())
+	}
+}
+
+func TestMemoryContext_RegInterceptor_Failure(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	RegInterceptor[greeter](func(inv *Invocation, proceed func()) {
+		proceed()
+	})
+	boom := errors.New("boom")
+	RegE(func() (*plainGreeter, error) {
+		return nil, boom
+	})
+	if _, err := resolveValue[greeter](<-GetContext().AskInterface((*greeter)(nil))); !errors.Is(err, boom) {
+		t.Errorf("Expected failure to pass through, got %v", err)
+	}
+}
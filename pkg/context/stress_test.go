@@ -0,0 +1,248 @@
+package context
+
+import (
+	"bytes"
+	stdcontext "context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+const stressScopes = 250
+
+type stressNamer interface {
+	Name() string
+}
+
+type stressLeaf struct {
+	scope string
+}
+
+type stressNode struct {
+	leaf *stressLeaf
+}
+
+type stressRoot struct {
+	node  *stressNode
+	namer stressNamer
+}
+
+func (l *stressLeaf) Name() string {
+	return l.scope
+}
+
+func stressScope(i int) string {
+	return fmt.Sprintf("stress%d", i)
+}
+
+func regStress(scope string) {
+	RegScoped(scope, func() *stressLeaf {
+		return &stressLeaf{scope}
+	})
+}
+
+func regStressNode(scope string) {
+	leaf := DepScoped[*stressLeaf](scope)
+	RegScoped(scope, func() *stressNode {
+		return &stressNode{ResolveDep[*stressLeaf](leaf)}
+	}, leaf)
+}
+
+func regStressRoot(scope string) {
+	node := DepScoped[*stressNode](scope)
+	namer := DepInterfaceScoped[stressNamer](scope)
+	RegScoped(scope, func() *stressRoot {
+		return &stressRoot{ResolveDep[*stressNode](node), ResolveDep[stressNamer](namer)}
+	}, node, namer)
+}
+
+func runConcurrently(t *testing.T, fns ...func() error) {
+	t.Helper()
+	errs := make(chan error, len(fns))
+	start := make(chan struct{})
+	wg := sync.WaitGroup{}
+	wg.Add(len(fns))
+	for _, fn := range fns {
+		go func(fn func() error) {
+			defer wg.Done()
+			<-start
+			if err := fn(); err != nil {
+				errs <- err
+			}
+		}(fn)
+	}
+	close(start)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func stressCalls(ctx stdcontext.Context, scope string) []func() error {
+	return []func() error{
+		func() error {
+			regStress(scope)
+			return nil
+		},
+		func() error {
+			regStressNode(scope)
+			return nil
+		},
+		func() error {
+			regStressRoot(scope)
+			return nil
+		},
+		func() error {
+			root, err := AskScopedCtx[*stressRoot](ctx, scope)
+			if err != nil {
+				return err
+			}
+			if root.node.leaf.scope != scope || root.namer.Name() != scope {
+				return fmt.Errorf("scope %s: unexpected root %v %v", scope, root.node.leaf.scope, root.namer.Name())
+			}
+			return nil
+		},
+		func() error {
+			node, err := AskScopedCtx[*stressNode](ctx, scope)
+			if err != nil {
+				return err
+			}
+			if node.leaf.scope != scope {
+				return fmt.Errorf("scope %s: unexpected node %v", scope, node.leaf.scope)
+			}
+			return nil
+		},
+		func() error {
+			namer, err := AskInterfaceScopedCtx[stressNamer](ctx, scope)
+			if err != nil {
+				return err
+			}
+			if namer.Name() != scope {
+				return fmt.Errorf("scope %s: unexpected namer %v", scope, namer.Name())
+			}
+			return nil
+		},
+	}
+}
+
+func TestMemoryContext_Stress(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), 10*time.Second)
+	defer cancel()
+
+	var fns []func() error
+	for i := 0; i < stressScopes; i++ {
+		fns = append(fns, stressCalls(ctx, stressScope(i))...)
+	}
+	fns = append(fns, func() error {
+		for i := 0; i < 50; i++ {
+			GetContext().Unresolved()
+			GetContext().GetUnresolvedRequests()
+			GetContext().StartupReport()
+			if err := ExportGraph(GraphJSON, &bytes.Buffer{}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, func() error {
+		for i := 0; i < 50; i++ {
+			Subscribe(NopListener{})()
+		}
+		return nil
+	})
+	runConcurrently(t, fns...)
+
+	if err := GetContext().WaitReady(ctx); err != nil {
+		t.Fatalf("Expected every bean to be ready, got %v", err)
+	}
+	if unresolved := GetContext().GetUnresolvedRequests(); len(unresolved) != 0 {
+		t.Errorf("Expected no pending requests, got %d", len(unresolved))
+	}
+}
+
+func TestMemoryContext_Stress_ChildContexts(t *testing.T) {
+	parent := NewMemoryContext()
+	useContext(t, parent)
+	ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), 10*time.Second)
+	defer cancel()
+
+	var fns []func() error
+	for i := 0; i < stressScopes; i++ {
+		scope := stressScope(i)
+		child := NewChildContext(parent)
+		fns = append(fns, func() error {
+			regStress(scope)
+			return nil
+		}, func() error {
+			child.RegScoped(scope, (**stressNode)(nil), func() interface{} {
+				return &stressNode{}
+			})
+			return nil
+		}, func() error {
+			_, err := awaitValue[*stressLeaf](ctx, child, scope, (**stressLeaf)(nil), child.AskScoped(scope, (**stressLeaf)(nil)))
+			return err
+		}, func() error {
+			_, err := awaitValue[stressNamer](ctx, child, scope, (*stressNamer)(nil), child.AskInterfaceScoped(scope, (*stressNamer)(nil)))
+			return err
+		}, func() error {
+			_, err := AskScopedCtx[*stressLeaf](ctx, scope)
+			return err
+		})
+	}
+	runConcurrently(t, fns...)
+}
+
+func TestMemoryContext_Stress_CancelAsk(t *testing.T) {
+	useContext(t, NewMemoryContext())
+
+	var fns []func() error
+	for i := 0; i < stressScopes; i++ {
+		scope := stressScope(i)
+		fns = append(fns, func() error {
+			ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), time.Millisecond)
+			defer cancel()
+			AskScopedCtx[*stressRoot](ctx, scope)
+			return nil
+		}, func() error {
+			regStress(scope)
+			return nil
+		}, func() error {
+			ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), 10*time.Second)
+			defer cancel()
+			_, err := AskInterfaceScopedCtx[stressNamer](ctx, scope)
+			return err
+		})
+	}
+	runConcurrently(t, fns...)
+
+	if unresolved := GetContext().GetUnresolvedRequests(); len(unresolved) != 0 {
+		t.Errorf("Expected cancelled requests to be removed, got %d", len(unresolved))
+	}
+}
+
+func TestMemoryContext_Stress_Conditions(t *testing.T) {
+	useContext(t, NewMemoryContext())
+
+	var fns []func() error
+	for i := 0; i < stressScopes; i++ {
+		scope := stressScope(i)
+		fns = append(fns, func() error {
+			RegScoped(scope, func() *stressLeaf {
+				return &stressLeaf{scope}
+			}, If(OnMissingBeanScoped[*stressLeaf](scope)))
+			return nil
+		}, func() error {
+			RegScoped(scope, func() *stressLeaf {
+				return &stressLeaf{"fallback"}
+			}, If(OnMissingBeanScoped[*stressLeaf](scope)))
+			return nil
+		})
+	}
+	runConcurrently(t, fns...)
+
+	if err := GetContext().WaitReady(stdcontext.Background()); err != nil {
+		t.Errorf("Expected one registration per scope, got %v", err)
+	}
+}
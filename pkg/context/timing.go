@@ -0,0 +1,114 @@
+package context
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+type (
+	BeanTiming struct {
+		Bean    BeanRef
+		Waiting time.Duration
+		Running time.Duration
+		Total   time.Duration
+	}
+
+	TimingReport struct {
+		Beans        []BeanTiming
+		CriticalPath []BeanTiming
+		Total        time.Duration
+	}
+)
+
+func (r *TimingReport) String() string {
+	b := &strings.Builder{}
+	w := tabwriter.NewWriter(b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "BEAN\tSCOPE\tWAITING\tRUNNING\tTOTAL")
+	for _, t := range r.Beans {
+		fmt.Fprintf(w, "%v\t%q\t%v\t%v\t%v\n", t.Bean.Type, t.Bean.Scope, t.Waiting, t.Running, t.Total)
+	}
+	w.Flush()
+	path := make([]string, 0, len(r.CriticalPath))
+	for _, t := range r.CriticalPath {
+		path = append(path, t.Bean.String())
+	}
+	fmt.Fprintf(b, "critical path (%v): %s\n", r.Total, strings.Join(path, " -> "))
+	return b.String()
+}
+
+func StartupReport() *TimingReport {
+	return GetContext().StartupReport()
+}
+
+func (m *memoryContext) StartupReport() *TimingReport {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	var finished []*registration
+	for _, scope := range m.registrations {
+		for _, reg := range scope {
+			if !reg.startedAt.IsZero() && !reg.finishedAt.IsZero() {
+				finished = append(finished, reg)
+			}
+		}
+	}
+	sortRegistrations(finished)
+
+	report := &TimingReport{Beans: make([]BeanTiming, 0, len(finished))}
+	if len(finished) == 0 {
+		return report
+	}
+	first, last := finished[0], finished[0]
+	for _, reg := range finished {
+		report.Beans = append(report.Beans, beanTiming(reg))
+		if reg.startedAt.Before(first.startedAt) {
+			first = reg
+		}
+		if reg.finishedAt.After(last.finishedAt) {
+			last = reg
+		}
+	}
+	sort.SliceStable(report.Beans, func(i, j int) bool {
+		return report.Beans[i].Total > report.Beans[j].Total
+	})
+	report.Total = last.finishedAt.Sub(first.startedAt)
+
+	visited := map[*registration]bool{}
+	for reg := last; reg != nil && !visited[reg]; reg = m.blockingDependency(reg) {
+		visited[reg] = true
+		report.CriticalPath = append([]BeanTiming{beanTiming(reg)}, report.CriticalPath...)
+	}
+	return report
+}
+
+func (m *memoryContext) blockingDependency(reg *registration) *registration {
+	var blocking *registration
+	for _, r := range reg.requests {
+		for _, target := range m.dependencyTargets(r) {
+			if target.startedAt.IsZero() || target.finishedAt.IsZero() || !target.finishedAt.After(reg.startedAt) {
+				continue
+			}
+			if blocking == nil || target.finishedAt.After(blocking.finishedAt) {
+				blocking = target
+			}
+		}
+	}
+	return blocking
+}
+
+func beanTiming(reg *registration) BeanTiming {
+	t := BeanTiming{
+		Bean:  newBeanRef(reg.scope, reg.typ),
+		Total: reg.finishedAt.Sub(reg.startedAt),
+	}
+	if reg.constructingAt.IsZero() {
+		t.Waiting = t.Total
+		return t
+	}
+	t.Waiting = reg.constructingAt.Sub(reg.startedAt)
+	t.Running = reg.finishedAt.Sub(reg.constructingAt)
+	return t
+}
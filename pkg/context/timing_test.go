@@ -0,0 +1,60 @@
+package context
+
+import (
+	stdcontext "context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMemoryContext_StartupReport(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	Reg(func() *firstIndependentStruct {
+		time.Sleep(30 * time.Millisecond)
+		return &firstIndependentStruct{"firstTestString"}
+	})
+	Reg(func() *secondIndependentStruct {
+		time.Sleep(5 * time.Millisecond)
+		return &secondIndependentStruct{"secondTestString"}
+	})
+	firstDep := Dep[*firstIndependentStruct]()
+	secondDep := Dep[*secondIndependentStruct]()
+	Reg(func() *dependentStruct {
+		time.Sleep(10 * time.Millisecond)
+		return &dependentStruct{
+			firstDep:  ResolveDep[*firstIndependentStruct](firstDep),
+			secondDep: ResolveDep[*secondIndependentStruct](secondDep),
+		}
+	}, firstDep, secondDep)
+	if err := WaitReady(stdcontext.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	report := StartupReport()
+	if len(report.Beans) != 3 {
+		t.Fatalf("Expected 3 beans, got %v", report.Beans)
+	}
+	for i := 1; i < len(report.Beans); i++ {
+		if report.Beans[i-1].Total < report.Beans[i].Total {
+			t.Errorf("Expected beans sorted by duration, got %v", report.Beans)
+		}
+	}
+
+	dependent := report.CriticalPath[len(report.CriticalPath)-1]
+	if dependent.Bean.Type != reflect.TypeOf((*dependentStruct)(nil)) {
+		t.Errorf("Expected critical path to end with *dependentStruct, got %v", dependent.Bean)
+	}
+	if dependent.Waiting < 20*time.Millisecond || dependent.Running < 10*time.Millisecond {
+		t.Errorf("Expected waiting and running time to be split, got %+v", dependent)
+	}
+	if len(report.CriticalPath) != 2 || report.CriticalPath[0].Bean.Type != reflect.TypeOf((*firstIndependentStruct)(nil)) {
+		t.Errorf("Expected *firstIndependentStruct to start the critical path, got %v", report.CriticalPath)
+	}
+	if report.Total < 40*time.Millisecond {
+		t.Errorf("Expected total startup of at least 40ms, got %v", report.Total)
+	}
+	if !strings.Contains(report.String(), "critical path") {
+		t.Errorf("Unexpected report:\n%s", report)
+	}
+}
@@ -0,0 +1,72 @@
+package context
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+)
+
+type typeIndex struct {
+	implementations map[string]map[reflect.Type][]*registration
+	lock            sync.Mutex
+}
+
+func implements(t reflect.Type, iface reflect.Type) bool {
+	return t.Elem().Implements(iface.Elem())
+}
+
+func (idx *typeIndex) lookup(s string, iface reflect.Type, registrations map[reflect.Type]*registration) []*registration {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+	if idx.implementations == nil {
+		idx.implementations = map[string]map[reflect.Type][]*registration{}
+	}
+	scope, ok := idx.implementations[s]
+	if !ok {
+		scope = map[reflect.Type][]*registration{}
+		idx.implementations[s] = scope
+	}
+	targets, ok := scope[iface]
+	if !ok {
+		for t, reg := range registrations {
+			if implements(t, iface) {
+				targets = append(targets, reg)
+			}
+		}
+		sortRegistrations(targets)
+		scope[iface] = targets
+	}
+	return append([]*registration(nil), targets...)
+}
+
+func (idx *typeIndex) add(reg *registration) {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+	scope := idx.implementations[reg.scope]
+	for iface, targets := range scope {
+		if !implements(reflect.TypeOf(reg.typ), iface) {
+			continue
+		}
+		i := sort.Search(len(targets), func(i int) bool {
+			return targets[i].order > reg.order
+		})
+		targets = append(targets, nil)
+		copy(targets[i+1:], targets[i:])
+		targets[i] = reg
+		scope[iface] = targets
+	}
+}
+
+func (idx *typeIndex) remove(reg *registration) {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+	scope := idx.implementations[reg.scope]
+	for iface, targets := range scope {
+		for i, target := range targets {
+			if target == reg {
+				scope[iface] = append(targets[:i:i], targets[i+1:]...)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package context
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestMemoryContext_TypeIndex_Registration(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	Reg(func() *plainGreeter {
+		return &plainGreeter{"plain"}
+	})
+	if greeting := AskInterface[greeter]().Greet(); greeting != "plain" {
+		t.Fatalf("Expected plain greeter, got %v", greeting)
+	}
+	Reg(func() *loudGreeter {
+		return &loudGreeter{&plainGreeter{"loud"}}
+	}, Primary())
+
+	if greeting := AskInterface[greeter]().Greet(); greeting != "LOUD!" {
+		t.Errorf("Expected index to pick up new primary implementation, got %v", greeting)
+	}
+}
+
+func TestMemoryContext_TypeIndex_Replace(t *testing.T) {
+	m := NewMemoryContext(WithDuplicatePolicy(DuplicateLastWins)).(*memoryContext)
+	m.Reg((**plainGreeter)(nil), func() interface{} {
+		return &plainGreeter{"first"}
+	}, Dep[*missingStruct]())
+	m.Reg((**loudGreeter)(nil), func() interface{} {
+		return &loudGreeter{&plainGreeter{"loud"}}
+	})
+	if !m.HasBean(DefaultScope, (*greeter)(nil)) {
+		t.Fatalf("Expected greeter implementations")
+	}
+	m.Reg((**plainGreeter)(nil), func() interface{} {
+		return &plainGreeter{"second"}
+	})
+
+	m.lock.RLock()
+	targets := m.implementations(DefaultScope, (*greeter)(nil))
+	m.lock.RUnlock()
+	if len(targets) != 2 || targets[0] != m.registrations[DefaultScope][reflect.TypeOf((**plainGreeter)(nil))] || targets[1].typ != (**loudGreeter)(nil) {
+		t.Errorf("Expected replaced registration to keep its place in the index, got %v", targets)
+	}
+}
+
+func TestMemoryContext_TypeIndex_ManyBeans(t *testing.T) {
+	m := NewMemoryContext().(*memoryContext)
+	var last any
+	for i := 0; i < 5000; i++ {
+		typ := reflect.StructOf([]reflect.StructField{{Name: fmt.Sprintf("Field%d", i), Type: reflect.TypeOf(0)}})
+		last = reflect.Zero(reflect.PointerTo(typ)).Interface()
+		m.Reg(last, func() interface{} {
+			return reflect.New(typ).Elem().Interface()
+		})
+	}
+	m.Reg((**plainGreeter)(nil), func() interface{} {
+		return &plainGreeter{"found"}
+	})
+
+	if greeting := (<-m.AskInterface((*greeter)(nil))).(greeter).Greet(); greeting != "found" {
+		t.Errorf("Expected greeter among many beans, got %v", greeting)
+	}
+	if value := <-m.Ask(last); reflect.TypeOf(value) != reflect.TypeOf(last).Elem() {
+		t.Errorf("Expected last bean, got %T", value)
+	}
+}
+
+func benchmarkContext(b *testing.B, beans int) *memoryContext {
+	b.Helper()
+	m := NewMemoryContext().(*memoryContext)
+	for i := 0; i < beans; i++ {
+		typ := reflect.StructOf([]reflect.StructField{{Name: fmt.Sprintf("Field%d", i), Type: reflect.TypeOf(0)}})
+		m.addRegistration(&registration{typ: reflect.Zero(reflect.PointerTo(typ)).Interface(), state: StateLazy})
+	}
+	m.addRegistration(&registration{typ: (**plainGreeter)(nil), state: StateLazy})
+	return m
+}
+
+func scanImplementations(m *memoryContext, s string, iface any) []*registration {
+	ifaceType := reflect.TypeOf(iface).Elem()
+	var targets []*registration
+	for t, reg := range m.registrations[s] {
+		if t.Elem().Implements(ifaceType) {
+			targets = append(targets, reg)
+		}
+	}
+	sortRegistrations(targets)
+	return targets
+}
+
+func BenchmarkImplementations(b *testing.B) {
+	for _, beans := range []int{10, 100, 1000} {
+		m := benchmarkContext(b, beans)
+		b.Run(fmt.Sprintf("scan/%d", beans), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				scanImplementations(m, DefaultScope, (*greeter)(nil))
+			}
+		})
+		b.Run(fmt.Sprintf("index/%d", beans), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				m.implementations(DefaultScope, (*greeter)(nil))
+			}
+		})
+	}
+}
+
+func BenchmarkAskInterface(b *testing.B) {
+	for _, beans := range []int{10, 100, 1000} {
+		b.Run(fmt.Sprint(beans), func(b *testing.B) {
+			m := benchmarkContext(b, beans)
+			m.storage[DefaultScope] = map[reflect.Type]interface{}{reflect.TypeOf((**plainGreeter)(nil)): &plainGreeter{}}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				<-m.AskInterface((*greeter)(nil))
+			}
+		})
+	}
+}
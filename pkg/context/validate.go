@@ -0,0 +1,225 @@
+package context
+
+import (
+	stdcontext "context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+type (
+	UnresolvedDependency struct {
+		Type         reflect.Type
+		Scope        string
+		Interface    bool
+		All          bool
+		BlockedBeans []BlockedBean
+	}
+
+	BlockedBean struct {
+		BeanRef
+		CallSite string
+	}
+
+	ValidationError struct {
+		Unresolved []UnresolvedDependency
+		Err        error
+	}
+
+	unresolvedKey struct {
+		scope       string
+		typ         any
+		toInterface bool
+		all         bool
+	}
+)
+
+func (u UnresolvedDependency) String() string {
+	kind := "direct"
+	switch {
+	case u.All:
+		kind = "all"
+	case u.Interface:
+		kind = "interface"
+	}
+	blocked := make([]string, 0, len(u.BlockedBeans))
+	for _, b := range u.BlockedBeans {
+		blocked = append(blocked, b.String())
+	}
+	return fmt.Sprintf("%v in scope %q (%s) blocks %s", u.Type, u.Scope, kind, strings.Join(blocked, ", "))
+}
+
+func (b BlockedBean) String() string {
+	if b.Type == nil {
+		return "Ask at " + b.CallSite
+	}
+	return fmt.Sprintf("%v registered at %s", b.BeanRef, b.CallSite)
+}
+
+func (e *ValidationError) Error() string {
+	lines := make([]string, 0, len(e.Unresolved)+1)
+	header := fmt.Sprintf("%d unresolved dependencies", len(e.Unresolved))
+	if e.Err != nil {
+		header = fmt.Sprintf("%v; %s", e.Err, header)
+	}
+	lines = append(lines, header+":")
+	for _, u := range e.Unresolved {
+		lines = append(lines, "\t"+u.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+func Validate() error {
+	return GetContext().Validate()
+}
+
+func WaitReady(ctx stdcontext.Context) error {
+	return GetContext().WaitReady(ctx)
+}
+
+func (m *memoryContext) Unresolved() []UnresolvedDependency {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.unresolved(false)
+}
+
+func (m *memoryContext) Validate() error {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	if unresolved := m.unresolved(true); len(unresolved) > 0 {
+		return &ValidationError{Unresolved: unresolved}
+	}
+	return nil
+}
+
+func (m *memoryContext) WaitReady(ctx stdcontext.Context) error {
+	for {
+		m.lock.RLock()
+		if unresolved := m.unresolved(true); len(unresolved) > 0 {
+			m.lock.RUnlock()
+			return &ValidationError{Unresolved: unresolved}
+		}
+		failures, pending := m.readiness()
+		changed := m.changed
+		m.lock.RUnlock()
+		if !pending {
+			return errors.Join(failures...)
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			m.lock.RLock()
+			defer m.lock.RUnlock()
+			return &ValidationError{Unresolved: m.unresolved(false), Err: ctx.Err()}
+		}
+	}
+}
+
+func (m *memoryContext) readiness() ([]error, bool) {
+	var regs []*registration
+	for _, scope := range m.registrations {
+		for _, reg := range scope {
+			regs = append(regs, reg)
+		}
+	}
+	sortRegistrations(regs)
+	var failures []error
+	pending := false
+	for _, reg := range regs {
+		switch {
+		case !reg.started:
+		case reg.state == StateFailed:
+			if failure, ok := m.storage[reg.scope][reflect.TypeOf(reg.typ)].(*beanFailure); ok {
+				failures = append(failures, failure.err)
+			}
+		case reg.state != StateReady:
+			pending = true
+		}
+	}
+	return failures, pending
+}
+
+func (m *memoryContext) unresolved(onlyUnsatisfiable bool) []UnresolvedDependency {
+	grouped := map[unresolvedKey]*UnresolvedDependency{}
+	add := func(w *dependencyRequest) {
+		key := unresolvedKey{scope: w.Scope, typ: w.Type, toInterface: w.toInterface, all: w.all}
+		u, ok := grouped[key]
+		if !ok {
+			u = &UnresolvedDependency{Type: reflect.TypeOf(w.Type).Elem(), Scope: w.Scope, Interface: w.toInterface, All: w.all}
+			grouped[key] = u
+		}
+		u.BlockedBeans = append(u.BlockedBeans, blockedBean(w))
+	}
+	for _, requests := range []map[string]map[reflect.Type][]*dependencyRequest{m.requests, m.interfaceRequests, m.allRequests} {
+		for _, scope := range requests {
+			for _, waiters := range scope {
+				for _, w := range waiters {
+					if w.isDelivered() || onlyUnsatisfiable && m.canResolve(w) {
+						continue
+					}
+					add(w)
+				}
+			}
+		}
+	}
+	for _, scope := range m.registrations {
+		for _, reg := range scope {
+			if reg.state != StateLazy {
+				continue
+			}
+			for _, r := range reg.requests {
+				if !m.canResolve(r) {
+					add(r)
+				}
+			}
+		}
+	}
+
+	unresolved := make([]UnresolvedDependency, 0, len(grouped))
+	for _, u := range grouped {
+		sort.Slice(u.BlockedBeans, func(i, j int) bool {
+			return u.BlockedBeans[i].String() < u.BlockedBeans[j].String()
+		})
+		unresolved = append(unresolved, *u)
+	}
+	sort.Slice(unresolved, func(i, j int) bool {
+		return unresolved[i].String() < unresolved[j].String()
+	})
+	return unresolved
+}
+
+func blockedBean(r *dependencyRequest) BlockedBean {
+	if r.owner == nil {
+		return BlockedBean{CallSite: r.callSite}
+	}
+	return BlockedBean{BeanRef: newBeanRef(r.owner.scope, r.owner.typ), CallSite: r.owner.callSite}
+}
+
+func (m *memoryContext) canResolve(r *dependencyRequest) bool {
+	switch {
+	case r.all:
+		return true
+	case r.toInterface:
+		if reg, err := m.resolveInterface(r.Scope, r.Type); err != nil || reg != nil {
+			return true
+		}
+	default:
+		if _, ok := m.registrations[r.Scope][reflect.TypeOf(r.Type)]; ok {
+			return true
+		}
+	}
+	return m.parent != nil && m.parent.lockedCanResolve(r)
+}
+
+func (m *memoryContext) lockedCanResolve(r *dependencyRequest) bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.canResolve(r)
+}
@@ -0,0 +1,179 @@
+package context
+
+import (
+	stdcontext "context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type missingInterface interface {
+	Missing()
+}
+
+func TestMemoryContext_Validate(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	missingDep := Dep[*missingStruct]()
+	Reg(func() *firstIndependentStruct {
+		ResolveDep[*missingStruct](missingDep)
+		return &firstIndependentStruct{"firstTestString"}
+	}, missingDep)
+	interfaceDep := DepInterfaceScoped[missingInterface]("custom")
+	RegScoped("custom", func() *secondIndependentStruct {
+		ResolveDep[missingInterface](interfaceDep)
+		return &secondIndependentStruct{"secondTestString"}
+	}, interfaceDep)
+	registeredDep := Dep[*firstIndependentStruct]()
+	Reg(func() *dependentStruct {
+		return &dependentStruct{firstDep: ResolveDep[*firstIndependentStruct](registeredDep)}
+	}, registeredDep)
+
+	err := Validate()
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected ValidationError, got %v", err)
+	}
+	if len(validationErr.Unresolved) != 2 {
+		t.Fatalf("Expected 2 unresolved dependencies, got %v", validationErr.Unresolved)
+	}
+
+	direct := validationErr.Unresolved[0]
+	if direct.Type != reflect.TypeOf((*missingStruct)(nil)) || direct.Scope != DefaultScope || direct.Interface {
+		t.Errorf("Unexpected direct dependency %v", direct)
+	}
+	if len(direct.BlockedBeans) != 1 || direct.BlockedBeans[0].Type != reflect.TypeOf((*firstIndependentStruct)(nil)) {
+		t.Errorf("Expected *firstIndependentStruct to be blocked, got %v", direct.BlockedBeans)
+	}
+	if !strings.Contains(direct.BlockedBeans[0].CallSite, "validate_test.go:") {
+		t.Errorf("Expected Reg call site, got %q", direct.BlockedBeans[0].CallSite)
+	}
+
+	iface := validationErr.Unresolved[1]
+	if iface.Type != reflect.TypeOf((*missingInterface)(nil)).Elem() || iface.Scope != "custom" || !iface.Interface {
+		t.Errorf("Unexpected interface dependency %v", iface)
+	}
+	if !strings.Contains(err.Error(), "2 unresolved dependencies") {
+		t.Errorf("Unexpected error message %q", err.Error())
+	}
+}
+
+func TestMemoryContext_Validate_AskCallSite(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	waiter := GetContext().Ask((*missingStruct)(nil))
+	defer GetContext().CancelAsk(DefaultScope, (*missingStruct)(nil), waiter)
+
+	var validationErr *ValidationError
+	if !errors.As(Validate(), &validationErr) || len(validationErr.Unresolved) != 1 {
+		t.Fatalf("Expected one unresolved dependency, got %v", validationErr)
+	}
+	blocked := validationErr.Unresolved[0].BlockedBeans
+	if len(blocked) != 1 || blocked[0].Type != nil || !strings.Contains(blocked[0].CallSite, "validate_test.go:") {
+		t.Errorf("Expected Ask call site, got %v", blocked)
+	}
+}
+
+func TestMemoryContext_WaitReady(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	rootErr := errors.New("boom")
+	RegE(func() (*firstIndependentStruct, error) {
+		time.Sleep(10 * time.Millisecond)
+		return nil, rootErr
+	})
+	Reg(func() *secondIndependentStruct {
+		return &secondIndependentStruct{"secondTestString"}
+	})
+	RegLazyScoped("lazy", func() *missingStruct {
+		return &missingStruct{}
+	})
+
+	ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), time.Second)
+	defer cancel()
+	if err := WaitReady(ctx); !errors.Is(err, rootErr) {
+		t.Errorf("Expected %v, got %v", rootErr, err)
+	}
+	if err := Validate(); err != nil {
+		t.Errorf("Expected no validation error, got %v", err)
+	}
+}
+
+func TestMemoryContext_WaitReady_Unsatisfiable(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	missingDep := Dep[*missingStruct]()
+	Reg(func() *firstIndependentStruct {
+		ResolveDep[*missingStruct](missingDep)
+		return &firstIndependentStruct{"firstTestString"}
+	}, missingDep)
+
+	var validationErr *ValidationError
+	if err := WaitReady(stdcontext.Background()); !errors.As(err, &validationErr) {
+		t.Fatalf("Expected ValidationError, got %v", err)
+	}
+	if validationErr.Err != nil || len(validationErr.Unresolved) != 1 {
+		t.Errorf("Unexpected validation error %v", validationErr)
+	}
+}
+
+func TestMemoryContext_Validate_Lazy(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	missingDep := Dep[*missingStruct]()
+	RegLazy(func() *firstIndependentStruct {
+		ResolveDep[*missingStruct](missingDep)
+		return &firstIndependentStruct{"firstTestString"}
+	}, missingDep)
+	firstDep := Dep[*firstIndependentStruct]()
+	RegLazy(func() *secondIndependentStruct {
+		return &secondIndependentStruct{ResolveDep[*firstIndependentStruct](firstDep).val}
+	}, firstDep)
+
+	var validationErr *ValidationError
+	if err := Validate(); !errors.As(err, &validationErr) {
+		t.Fatalf("Expected ValidationError, got %v", err)
+	}
+	if len(validationErr.Unresolved) != 1 || validationErr.Unresolved[0].Type.String() != "*context.missingStruct" {
+		t.Errorf("Expected only the missing dependency of the lazy bean, got %v", validationErr.Unresolved)
+	}
+	if blocked := validationErr.Unresolved[0].BlockedBeans; len(blocked) != 1 || blocked[0].Type.String() != "*context.firstIndependentStruct" {
+		t.Errorf("Expected the lazy bean to be blocked, got %v", blocked)
+	}
+	if err := WaitReady(stdcontext.Background()); !errors.As(err, &validationErr) {
+		t.Errorf("Expected WaitReady to report the lazy bean, got %v", err)
+	}
+}
+
+func TestMemoryContext_WaitReady_Timeout(t *testing.T) {
+	useContext(t, NewMemoryContext())
+	release := make(chan struct{})
+	defer close(release)
+	Reg(func() *firstIndependentStruct {
+		<-release
+		return &firstIndependentStruct{"firstTestString"}
+	})
+
+	ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := WaitReady(ctx); !errors.Is(err, stdcontext.DeadlineExceeded) {
+		t.Errorf("Expected deadline error, got %v", err)
+	}
+}
+
+func TestChildContext_Validate_ParentRegistration(t *testing.T) {
+	parent := NewMemoryContext()
+	parent.Reg((*firstIndependentStruct)(nil), func() interface{} {
+		return &firstIndependentStruct{"firstTestString"}
+	})
+	child := NewChildContext(parent)
+	waiter := child.Ask((*firstIndependentStruct)(nil))
+	<-waiter
+	waiter = child.AskInterface((*missingInterface)(nil))
+	defer child.CancelAsk(DefaultScope, (*missingInterface)(nil), waiter)
+
+	var validationErr *ValidationError
+	if !errors.As(child.Validate(), &validationErr) || len(validationErr.Unresolved) != 1 {
+		t.Fatalf("Expected one unresolved dependency, got %v", validationErr)
+	}
+	if !validationErr.Unresolved[0].Interface {
+		t.Errorf("Expected interface dependency, got %v", validationErr.Unresolved[0])
+	}
+}